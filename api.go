@@ -0,0 +1,337 @@
+package healthchecks
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxAPIResponseSize limits the number of bytes read from a management API response.
+const maxAPIResponseSize = 16 << 20
+
+// CheckState is the status of a check as reported by the management API.
+type CheckState string
+
+const (
+	// StateNew is the status of a check which hasn't received any ping yet.
+	StateNew CheckState = "new"
+	// StateStarted is the status of a check which received the "start" signal.
+	StateStarted CheckState = "started"
+	// StateUp is the status of a check which received the "success" signal in time.
+	StateUp CheckState = "up"
+	// StateGrace is the status of a late check within its grace time.
+	StateGrace CheckState = "grace"
+	// StateDown is the status of a failed or overdue check.
+	StateDown CheckState = "down"
+	// StatePaused is the status of a paused check.
+	StatePaused CheckState = "paused"
+)
+
+// CheckInfo describes a check as returned by the management API.
+type CheckInfo struct {
+	// Name is the name of the check.
+	Name string
+	// Slug is the slug of the check.
+	Slug string
+	// Tags are the tags of the check.
+	Tags []string
+	// Desc is the description of the check.
+	Desc string
+	// UUID identifies the check. It is only available for read-write API keys.
+	UUID string
+	// UniqueKey identifies the check without revealing its UUID. It is returned for read-only API keys
+	// and derived from the UUID like healthchecks.io does for read-write API keys.
+	UniqueKey string
+	// Status is the current status of the check.
+	Status CheckState
+	// Started reports whether the check received the "start" signal without a following "success" or "fail" yet.
+	Started bool
+	// NPings is the number of pings the check received.
+	NPings int
+	// LastPing is the time of the last ping, zero if the check hasn't received any ping yet.
+	LastPing time.Time
+	// NextPing is the time the next ping is expected, zero if not applicable.
+	NextPing time.Time
+	// Timeout is the expected period between pings. It is zero for checks with a cron schedule.
+	Timeout time.Duration
+	// Grace is the time to wait for a late ping before the check goes down.
+	Grace time.Duration
+	// Schedule is the cron expression of checks with a cron schedule.
+	Schedule string
+	// Timezone is the time zone of the cron expression.
+	Timezone string
+}
+
+// ID returns the UUID of the check or, for read-only API keys, its unique key.
+func (c *CheckInfo) ID() string {
+	if c.UUID != "" {
+		return c.UUID
+	}
+	return c.UniqueKey
+}
+
+// checkInfoJSON is the representation of [CheckInfo] returned by the management API.
+type checkInfoJSON struct {
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	Tags      string     `json:"tags"`
+	Desc      string     `json:"desc"`
+	UUID      string     `json:"uuid"`
+	UniqueKey string     `json:"unique_key"`
+	Status    CheckState `json:"status"`
+	Started   bool       `json:"started"`
+	NPings    int        `json:"n_pings"`
+	LastPing  *time.Time `json:"last_ping"`
+	NextPing  *time.Time `json:"next_ping"`
+	Timeout   float64    `json:"timeout"`
+	Grace     float64    `json:"grace"`
+	Schedule  string     `json:"schedule"`
+	Timezone  string     `json:"tz"`
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (c *CheckInfo) UnmarshalJSON(b []byte) error {
+	var raw checkInfoJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*c = CheckInfo{
+		Name:      raw.Name,
+		Slug:      raw.Slug,
+		Tags:      strings.Fields(raw.Tags),
+		Desc:      raw.Desc,
+		UUID:      raw.UUID,
+		UniqueKey: raw.UniqueKey,
+		Status:    raw.Status,
+		Started:   raw.Started,
+		NPings:    raw.NPings,
+		Timeout:   time.Duration(raw.Timeout * float64(time.Second)),
+		Grace:     time.Duration(raw.Grace * float64(time.Second)),
+		Schedule:  raw.Schedule,
+		Timezone:  raw.Timezone,
+	}
+	if c.UniqueKey == "" && c.UUID != "" {
+		c.UniqueKey = uniqueKey(c.UUID)
+	}
+	if raw.LastPing != nil {
+		c.LastPing = *raw.LastPing
+	}
+	if raw.NextPing != nil {
+		c.NextPing = *raw.NextPing
+	}
+	return nil
+}
+
+// uniqueKey derives the unique key of a check from its UUID, as healthchecks.io does: the SHA-1 hex digest
+// of the first 16 hex digits of the UUID.
+func uniqueKey(uuid string) string {
+	code := strings.ReplaceAll(uuid, "-", "")
+	if len(code) > 16 {
+		code = code[:16]
+	}
+	sum := sha1.Sum([]byte(code)) //nolint:gosec // not used for security, matches healthchecks.io
+	return hex.EncodeToString(sum[:])
+}
+
+// Flip is a change of the status of a check between up and down.
+type Flip struct {
+	// Time is the time of the change.
+	Time time.Time
+	// Up reports whether the check changed to up.
+	// Changes to down include pausing the check, the management API doesn't distinguish them.
+	Up bool
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (f *Flip) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Timestamp time.Time `json:"timestamp"`
+		Up        int       `json:"up"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*f = Flip{Time: raw.Timestamp, Up: raw.Up == 1}
+	return nil
+}
+
+// PingInfo is a ping logged by a check, as returned by the management API.
+type PingInfo struct {
+	// Kind is the kind of the ping: "success", "start", "fail", "log" or "ign" for ignored pings.
+	// Pings with a non-zero exit status are of kind "fail".
+	Kind string
+	// Time is the time the ping was received.
+	Time time.Time
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (p *PingInfo) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Type string    `json:"type"`
+		Date time.Time `json:"date"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*p = PingInfo{Kind: raw.Type, Time: raw.Date}
+	return nil
+}
+
+// APIClient accesses the management API of healthchecks.io.
+//
+// Use [NewAPIClient] for obtaining a new instance.
+type APIClient struct {
+	apiKey string
+	opts   *options
+}
+
+// NewAPIClient creates a new instance of [APIClient].
+//
+// The API key can be created under your project's settings. Read-only keys are sufficient for listing checks.
+// The URL of the API is derived from the ping URL, see [WithAPIURL].
+func NewAPIClient(apiKey string, opts ...Option) (*APIClient, error) {
+	if apiKey == "" {
+		return nil, errors.New("API key must not be empty")
+	}
+	options, err := optsFromDefaults(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &APIClient{
+		apiKey: apiKey,
+		opts:   options,
+	}, nil
+}
+
+// apiURL returns the root URL of the management API.
+func (c *APIClient) apiURL() *url.URL {
+	if c.opts.APIURL != nil {
+		return c.opts.APIURL
+	}
+	if c.opts.RootURL.Host == "hc-ping.com" {
+		return mustURL("https://healthchecks.io")
+	}
+	u := *c.opts.RootURL
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/ping")
+	u.RawPath = ""
+	return &u
+}
+
+// ListChecks returns all checks of the project.
+//
+// If tags are given, only checks with all of them are returned.
+func (c *APIClient) ListChecks(ctx context.Context, tags ...string) ([]CheckInfo, error) {
+	u := c.apiURL().JoinPath("api", "v3", "checks") // trailing slash required
+	u.Path += "/"
+	query := url.Values{}
+	for _, tag := range tags {
+		query.Add("tag", tag)
+	}
+	u.RawQuery = query.Encode()
+
+	var resp struct {
+		Checks []CheckInfo `json:"checks"`
+	}
+	if err := c.get(ctx, u, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Checks, nil
+}
+
+// ListFlips returns the status changes of the check with id, its UUID or unique key, ordered by time.
+//
+// If start is not zero, only flips after start are returned.
+func (c *APIClient) ListFlips(ctx context.Context, id string, start time.Time) ([]Flip, error) {
+	u := c.apiURL().JoinPath("api", "v3", "checks", id, "flips") // trailing slash required
+	u.Path += "/"
+	if !start.IsZero() {
+		u.RawQuery = url.Values{"start": {strconv.FormatInt(start.Unix(), 10)}}.Encode()
+	}
+
+	var flips []Flip
+	if err := c.get(ctx, u, &flips); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(flips, func(i, j int) bool {
+		return flips[i].Time.Before(flips[j].Time)
+	})
+	return flips, nil
+}
+
+// ListPings returns the logged pings of the check with uuid, ordered by time.
+//
+// It requires a read-write API key. Only the most recent pings are kept, depending on the plan of the account.
+func (c *APIClient) ListPings(ctx context.Context, uuid string) ([]PingInfo, error) {
+	u := c.apiURL().JoinPath("api", "v3", "checks", uuid, "pings") // trailing slash required
+	u.Path += "/"
+
+	var resp struct {
+		Pings []PingInfo `json:"pings"`
+	}
+	if err := c.get(ctx, u, &resp); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(resp.Pings, func(i, j int) bool {
+		return resp.Pings[i].Time.Before(resp.Pings[j].Time)
+	})
+	return resp.Pings, nil
+}
+
+// get requests u and decodes the JSON response into v.
+func (c *APIClient) get(ctx context.Context, u *url.URL, v any) error {
+	if c.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", redactError(err, redactCheckID(u)))
+	}
+	req.Header.Set("X-Api-Key", c.apiKey)
+
+	resp, _, err := do(c.opts.HTTPClient, req, true)
+	if err != nil {
+		return fmt.Errorf("requesting: %w", redactError(err, redactCheckID(u)))
+	}
+	defer func() {
+		// drain, so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		respBody, err := readResponse(resp)
+		if err != nil {
+			respBody = []byte("no information")
+		}
+		return fmt.Errorf("HTTP response status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxAPIResponseSize)).Decode(v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// redactCheckID returns a copy of u with the check ID following /checks/ redacted, since UUIDs are secrets.
+func redactCheckID(u *url.URL) *url.URL {
+	r := *u
+	segments := strings.Split(r.Path, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == "checks" && segments[i+1] != "" {
+			segments[i+1] = redacted
+		}
+	}
+	r.Path = strings.Join(segments, "/")
+	r.RawPath = r.Path // keep asterisks unescaped
+	return &r
+}
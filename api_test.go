@@ -0,0 +1,199 @@
+package healthchecks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testChecksResponse = `{"checks": [
+	{"name": "Backup", "slug": "backup", "tags": "prod db", "desc": "", "grace": 3600, "n_pings": 42,
+	 "status": "up", "started": false, "last_ping": "2024-01-02T03:04:05+00:00", "next_ping": "2024-01-03T03:04:05+00:00",
+	 "manual_resume": false, "methods": "", "uuid": "6da9bc25-880d-4a73-a0e5-e833405e206f", "timeout": 86400},
+	{"name": "Cron", "slug": "cron", "tags": "", "desc": "nightly", "grace": 60, "n_pings": 0,
+	 "status": "new", "started": false, "last_ping": null, "next_ping": null,
+	 "unique_key": "a6c7b0a8a66bed0df66abfdab3c77736861703ee", "schedule": "0 3 * * *", "tz": "Europe/Berlin"}
+]}`
+
+func TestAPIClientListChecks(t *testing.T) {
+	var gotPath, gotQuery, gotKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery, gotKey = r.URL.Path, r.URL.RawQuery, r.Header.Get("X-Api-Key")
+		_, _ = io.WriteString(w, testChecksResponse)
+	}))
+	defer srv.Close()
+
+	client, err := NewAPIClient("apikey", WithURL(srv.URL+"/ping"))
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v", err)
+	}
+	checks, err := client.ListChecks(context.Background(), "prod", "db")
+	if err != nil {
+		t.Fatalf("ListChecks() error = %v", err)
+	}
+
+	if gotPath != "/api/v3/checks/" || gotQuery != "tag=prod&tag=db" || gotKey != "apikey" {
+		t.Errorf("request = %s?%s with key %s", gotPath, gotQuery, gotKey)
+	}
+	want := []CheckInfo{
+		{
+			Name:      "Backup",
+			Slug:      "backup",
+			Tags:      []string{"prod", "db"},
+			UUID:      "6da9bc25-880d-4a73-a0e5-e833405e206f",
+			UniqueKey: "82fe8f4a6c34a8f02e4d0afed71360b33138dff8", // derived from the UUID
+			Status:    StateUp,
+			NPings:    42,
+			LastPing:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			NextPing:  time.Date(2024, 1, 3, 3, 4, 5, 0, time.UTC),
+			Timeout:   24 * time.Hour,
+			Grace:     time.Hour,
+		},
+		{
+			Name:      "Cron",
+			Slug:      "cron",
+			Tags:      []string{},
+			Desc:      "nightly",
+			UniqueKey: "a6c7b0a8a66bed0df66abfdab3c77736861703ee",
+			Status:    StateNew,
+			Grace:     time.Minute,
+			Schedule:  "0 3 * * *",
+			Timezone:  "Europe/Berlin",
+		},
+	}
+	// compare times separately, since their locations differ
+	for i := range checks {
+		if !checks[i].LastPing.Equal(want[i].LastPing) || !checks[i].NextPing.Equal(want[i].NextPing) {
+			t.Errorf("check %d: times = %v, %v, want %v, %v", i, checks[i].LastPing, checks[i].NextPing, want[i].LastPing, want[i].NextPing)
+		}
+		checks[i].LastPing, checks[i].NextPing = want[i].LastPing, want[i].NextPing
+	}
+	if !reflect.DeepEqual(checks, want) {
+		t.Errorf("ListChecks() = %+v, want %+v", checks, want)
+	}
+	if id := checks[1].ID(); id != want[1].UniqueKey {
+		t.Errorf("ID() = %s, want %s", id, want[1].UniqueKey)
+	}
+}
+
+func TestAPIClientError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "wrong api key"}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	client, err := NewAPIClient("wrong", WithAPIURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v", err)
+	}
+	_, err = client.ListChecks(context.Background())
+	if want := `HTTP response status 401: {"error": "wrong api key"}`; err == nil || err.Error() != want {
+		t.Errorf("ListChecks() error = %v, want %s", err, want)
+	}
+}
+
+func TestAPIClientURL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default", opts: nil, want: "https://healthchecks.io"},
+		{name: "self-hosted", opts: []Option{WithURL("https://hc.example.com/ping/")}, want: "https://hc.example.com"},
+		{name: "self-hosted with prefix", opts: []Option{WithURL("https://example.com/hc/ping")}, want: "https://example.com/hc"},
+		{name: "explicit", opts: []Option{WithURL("https://ping.example.com"), WithAPIURL("https://api.example.com")}, want: "https://api.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewAPIClient("apikey", tt.opts...)
+			if err != nil {
+				t.Fatalf("NewAPIClient() error = %v", err)
+			}
+			if got := client.apiURL().String(); got != tt.want {
+				t.Errorf("apiURL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewAPIClient(""); err == nil {
+		t.Error("NewAPIClient() with empty key error = nil, want error")
+	}
+	if _, err := NewAPIClient("apikey", WithAPIURL("/api")); err == nil {
+		t.Error("NewAPIClient() with invalid API URL error = nil, want error")
+	}
+}
+
+func TestAPIClientListFlips(t *testing.T) {
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		_, _ = io.WriteString(w, `[
+			{"timestamp": "2024-01-02T04:00:00+00:00", "up": 1},
+			{"timestamp": "2024-01-02T03:00:00+00:00", "up": 0}
+		]`)
+	}))
+	defer srv.Close()
+
+	client, err := NewAPIClient("apikey", WithAPIURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v", err)
+	}
+	flips, err := client.ListFlips(context.Background(), "backup-id", time.Unix(1704067200, 0))
+	if err != nil {
+		t.Fatalf("ListFlips() error = %v", err)
+	}
+	if gotPath != "/api/v3/checks/backup-id/flips/" || gotQuery != "start=1704067200" {
+		t.Errorf("request = %s?%s", gotPath, gotQuery)
+	}
+	if len(flips) != 2 {
+		t.Fatalf("got %d flips, want 2", len(flips))
+	}
+	// sorted by time
+	if want := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC); !flips[0].Time.Equal(want) || flips[0].Up {
+		t.Errorf("flips[0] = %+v, want down at %v", flips[0], want)
+	}
+	if want := time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC); !flips[1].Time.Equal(want) || !flips[1].Up {
+		t.Errorf("flips[1] = %+v, want up at %v", flips[1], want)
+	}
+}
+
+func TestAPIClientListPings(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = io.WriteString(w, `{"pings": [
+			{"type": "success", "date": "2024-01-02T04:00:00+00:00", "n": 2, "scheme": "https", "method": "GET"},
+			{"type": "start", "date": "2024-01-02T03:00:00+00:00", "n": 1, "scheme": "https", "method": "GET"}
+		]}`)
+	}))
+	defer srv.Close()
+
+	client, err := NewAPIClient("apikey", WithAPIURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v", err)
+	}
+	pings, err := client.ListPings(context.Background(), "backup-id")
+	if err != nil {
+		t.Fatalf("ListPings() error = %v", err)
+	}
+	if gotPath != "/api/v3/checks/backup-id/pings/" {
+		t.Errorf("path = %s", gotPath)
+	}
+	// sorted by time
+	want := []PingInfo{
+		{Kind: "start", Time: time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{Kind: "success", Time: time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC)},
+	}
+	if len(pings) != len(want) {
+		t.Fatalf("got %d pings, want %d", len(pings), len(want))
+	}
+	for i := range want {
+		if pings[i].Kind != want[i].Kind || !pings[i].Time.Equal(want[i].Time) {
+			t.Errorf("pings[%d] = %+v, want %+v", i, pings[i], want[i])
+		}
+	}
+}
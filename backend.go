@@ -0,0 +1,119 @@
+package healthchecks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrUnsupportedSignal is returned by [Backend.NewRequest] for signals the monitoring service has no equivalent of.
+// These signals are skipped without an error, so code depending on [Notifier] works with any backend.
+var ErrUnsupportedSignal = errors.New("signal not supported by backend")
+
+// Ping is a signal to be sent by a [Backend].
+type Ping struct {
+	// Signal is the signal to send.
+	Signal Signal
+	// Body is attached to the signal, nil if there is none.
+	Body []byte
+	// RunID is the run ID of the signal, empty if there is none (see [WithRunID]).
+	RunID string
+	// Create reports whether the check should be created if it doesn't exist yet (see [WithAutoCreate]).
+	Create bool
+}
+
+// Backend maps signals to HTTP requests of a monitoring service and interprets its responses.
+//
+// Checks use healthchecks.io by default. Use [NewCheck] for checks of other services,
+// e.g. with [NewUptimeKumaBackend] or [NewTemplateBackend].
+type Backend interface {
+	// NewRequest creates the request for sending p.
+	//
+	// It returns [ErrUnsupportedSignal] for signals which the service has no equivalent of.
+	NewRequest(ctx context.Context, p Ping) (*http.Request, error)
+	// CheckResponse returns an error if resp indicates that the signal wasn't accepted.
+	//
+	// body holds the beginning of the response body, resp.Body must not be read.
+	CheckResponse(resp *http.Response, body []byte) error
+}
+
+// NewCheck creates a new instance of [Check], sending signals via b.
+//
+// Options concerning the HTTP client, delivery and rate limiting apply as for healthchecks.io checks.
+// Options concerning the URL are ignored.
+func NewCheck(b Backend, opts ...Option) (*Check, error) {
+	if b == nil {
+		return nil, errors.New("backend must not be nil")
+	}
+	options, err := notifierOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Check{
+		backend: b,
+		opts:    options,
+	}, nil
+}
+
+// compile-time interface implementation check
+var _ Backend = (*healthchecksBackend)(nil)
+
+// healthchecksBackend sends signals to healthchecks.io.
+type healthchecksBackend struct {
+	root *url.URL
+	path string // UUID or <ping-key>/<slug>
+}
+
+// checkURL returns the ping URL of the check without signal suffix.
+func (b *healthchecksBackend) checkURL() *url.URL {
+	return b.root.JoinPath(b.path)
+}
+
+// pingURL returns the URL for sending p.
+func (b *healthchecksBackend) pingURL(p Ping) *url.URL {
+	u := b.checkURL().JoinPath(p.Signal.suffix()...)
+	query := url.Values{}
+	if p.Create {
+		query.Set("create", "1")
+	}
+	if p.RunID != "" {
+		query.Set("rid", p.RunID)
+	}
+	u.RawQuery = query.Encode()
+	return u
+}
+
+func (b *healthchecksBackend) NewRequest(ctx context.Context, p Ping) (*http.Request, error) {
+	return newRequest(ctx, b.pingURL(p).String(), p.Body)
+}
+
+func (b *healthchecksBackend) CheckResponse(resp *http.Response, body []byte) error {
+	respStr := string(body)
+	// if not 200, we return an error with the response body
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP response status %d: %s", resp.StatusCode, respStr)
+	}
+	// if the body is "OK", it's an actual good response
+	if respStr != "OK" {
+		return fmt.Errorf("HTTP response not OK: '%s'", respStr)
+	}
+	return nil
+}
+
+// redacted returns a copy of b with the secret part of its path redacted.
+func (b *healthchecksBackend) redacted() *healthchecksBackend {
+	return &healthchecksBackend{root: b.root, path: redactPath(b.path)}
+}
+
+// newRequest creates a new request for u, using POST if body is non-nil.
+//
+// The request can be retried, since its body can be replayed (see [do]).
+func newRequest(ctx context.Context, u string, body []byte) (*http.Request, error) {
+	if body == nil {
+		return http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	}
+	return http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+}
@@ -0,0 +1,130 @@
+package healthchecks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// fakeBackend sends all signals but "log" to url, recording the pings.
+type fakeBackend struct {
+	url   string
+	pings []Ping
+}
+
+func (b *fakeBackend) NewRequest(ctx context.Context, p Ping) (*http.Request, error) {
+	if p.Signal == SignalLog {
+		return nil, fmt.Errorf("no logs: %w", ErrUnsupportedSignal)
+	}
+	b.pings = append(b.pings, p)
+	return newRequest(ctx, b.url+"/secret/"+p.Signal.String(), p.Body)
+}
+
+func (b *fakeBackend) CheckResponse(resp *http.Response, body []byte) error {
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
+func TestNewCheck(t *testing.T) {
+	var gotPaths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPaths = append(gotPaths, r.URL.Path)
+		if r.URL.Path == "/secret/fail" {
+			http.Error(w, "nope", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	b := &fakeBackend{url: srv.URL}
+	c, err := NewCheck(b, WithRunID("6da9bc25-880d-4a73-a0e5-e833405e206f"))
+	if err != nil {
+		t.Fatalf("NewCheck() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		t.Errorf("Start() error = %v", err)
+	}
+	if err := c.Log(ctx, "skipped"); err != nil {
+		t.Errorf("Log() error = %v, want nil for unsupported signal", err)
+	}
+	if err := c.Send(ctx, SignalSuccess, WithBody("done"), WithCallAutoCreate()); err != nil {
+		t.Errorf("Send() error = %v", err)
+	}
+	if err := c.Fail(ctx); err == nil || err.Error() != "status 400: nope\n" {
+		t.Errorf("Fail() error = %v, want error from CheckResponse", err)
+	}
+
+	if want := []string{"/secret/start", "/secret/success", "/secret/fail"}; !reflect.DeepEqual(gotPaths, want) {
+		t.Errorf("paths = %v, want %v", gotPaths, want)
+	}
+	wantPings := []Ping{
+		{Signal: SignalStart, RunID: "6da9bc25-880d-4a73-a0e5-e833405e206f"},
+		{Signal: SignalSuccess, Body: []byte("done"), RunID: "6da9bc25-880d-4a73-a0e5-e833405e206f", Create: true},
+		{Signal: SignalFail, RunID: "6da9bc25-880d-4a73-a0e5-e833405e206f"},
+	}
+	if !reflect.DeepEqual(b.pings, wantPings) {
+		t.Errorf("pings = %+v, want %+v", b.pings, wantPings)
+	}
+
+	if _, err := NewCheck(nil); err == nil {
+		t.Error("NewCheck(nil) error = nil, want error")
+	}
+}
+
+func TestNewCheckOptions(t *testing.T) {
+	b := &fakeBackend{url: "https://example.com"}
+
+	// disabled checks don't even create requests
+	c, err := NewCheck(b, WithDisabled())
+	if err != nil {
+		t.Fatalf("NewCheck() error = %v", err)
+	}
+	if err := c.Success(context.Background()); err != nil || len(b.pings) != 0 {
+		t.Errorf("Success() with disabled check error = %v, pings = %v", err, b.pings)
+	}
+
+	// dry runs redact the whole path
+	var buf bytes.Buffer
+	c, err = NewCheck(b, WithDryRun(&buf))
+	if err != nil {
+		t.Fatalf("NewCheck() error = %v", err)
+	}
+	if err := c.Send(context.Background(), SignalFail, WithBody("oops")); err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+	if want := "healthchecks dry run: signal=fail method=POST url=https://example.com/**** body=\"oops\"\n"; buf.String() != want {
+		t.Errorf("dry run = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestHealthchecksBackendResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "ok", status: http.StatusOK, body: "OK"},
+		{name: "not found", status: http.StatusOK, body: "OK (not found)", wantErr: errors.New("HTTP response not OK: 'OK (not found)'")},
+		{name: "rate limited", status: http.StatusTooManyRequests, body: "rate limited", wantErr: errors.New("HTTP response status 429: rate limited")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Body: io.NopCloser(http.NoBody)}
+			err := (&healthchecksBackend{}).CheckResponse(resp, []byte(tt.body))
+			if fmt.Sprint(err) != fmt.Sprint(tt.wantErr) {
+				t.Errorf("CheckResponse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package healthchecks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned for signals which weren't sent because the [CircuitBreaker] is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a [CircuitBreaker].
+type CircuitState int
+
+const (
+	// CircuitClosed lets all requests pass.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails all requests with [ErrCircuitOpen].
+	CircuitOpen
+	// CircuitHalfOpen lets a single request pass, which decides whether to close or open the circuit again.
+	CircuitHalfOpen
+)
+
+// String returns the name of s.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker fails signals fast while the endpoint is unreachable, instead of waiting for the timeout
+// of each request.
+//
+// After a number of consecutive transport errors (e.g. timeouts or refused connections), the circuit opens and
+// signals fail with [ErrCircuitOpen]. After a cooldown, a single signal is sent as probe: if it receives a
+// response, the circuit closes again, otherwise it stays open for another cooldown.
+// Responses with an error status count as success, since the endpoint is reachable.
+//
+// Use [NewCircuitBreaker] for obtaining a new instance and pass it to all checks pinging the same endpoint
+// using [WithCircuitBreaker]. It is safe for concurrent use.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	onChange  func(from, to CircuitState)
+
+	now func() time.Time // defaults to time.Now if nil
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int       // consecutive transport errors
+	openedAt time.Time // time the circuit was opened
+	probing  bool      // whether a probe is in flight in half-open state
+}
+
+// NewCircuitBreaker creates a new [CircuitBreaker], which opens after threshold consecutive transport errors
+// and probes the endpoint again after cooldown.
+//
+// onChange is called on each state change, if non-nil. It may be called concurrently.
+func NewCircuitBreaker(threshold int, cooldown time.Duration, onChange func(from, to CircuitState)) (*CircuitBreaker, error) {
+	if threshold < 1 {
+		return nil, fmt.Errorf("threshold is %d, needs to be >= 1", threshold)
+	}
+	if cooldown <= 0 {
+		return nil, fmt.Errorf("cooldown is %d, needs to be > 0", cooldown)
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		onChange:  onChange,
+	}, nil
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == CircuitOpen && cb.cooledDown() {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+// cooledDown reports whether the cooldown of the open circuit has passed.
+//
+// The caller must hold cb.mu.
+func (cb *CircuitBreaker) cooledDown() bool {
+	return cb.clock().Sub(cb.openedAt) >= cb.cooldown
+}
+
+func (cb *CircuitBreaker) clock() time.Time {
+	if cb.now != nil {
+		return cb.now()
+	}
+	return time.Now()
+}
+
+// acquire returns [ErrCircuitOpen] if a request may not be sent.
+//
+// Otherwise, the returned function needs to be called with the context and the error of the request.
+// A nil breaker allows all requests.
+func (cb *CircuitBreaker) acquire() (func(ctx context.Context, err error), error) {
+	if cb == nil {
+		return func(context.Context, error) {}, nil
+	}
+	cb.mu.Lock()
+	from := cb.state
+	switch {
+	case cb.state == CircuitOpen && cb.cooledDown():
+		cb.state = CircuitHalfOpen
+	case cb.state == CircuitOpen, cb.state == CircuitHalfOpen && cb.probing:
+		cb.mu.Unlock()
+		return nil, ErrCircuitOpen
+	}
+	probe := cb.state == CircuitHalfOpen
+	cb.probing = cb.probing || probe
+	to := cb.state
+	cb.mu.Unlock()
+
+	cb.changed(from, to)
+	return func(ctx context.Context, err error) {
+		cb.record(ctx, err, probe)
+	}, nil
+}
+
+// record updates the state with the result of a request, where probe reports whether the request
+// was sent in half-open state.
+func (cb *CircuitBreaker) record(ctx context.Context, err error, probe bool) {
+	cb.mu.Lock()
+	from := cb.state
+	if probe {
+		cb.probing = false
+	}
+	switch {
+	case err != nil && errors.Is(ctx.Err(), context.Canceled):
+		// cancelled by the caller, which says nothing about the endpoint
+	case err != nil:
+		cb.failures++
+		if probe || (cb.state == CircuitClosed && cb.failures >= cb.threshold) {
+			cb.state = CircuitOpen
+			cb.openedAt = cb.clock()
+		}
+	default:
+		cb.failures = 0
+		cb.state = CircuitClosed
+	}
+	to := cb.state
+	cb.mu.Unlock()
+
+	cb.changed(from, to)
+}
+
+// changed calls the callback if the state changed.
+func (cb *CircuitBreaker) changed(from, to CircuitState) {
+	if from != to && cb.onChange != nil {
+		cb.onChange(from, to)
+	}
+}
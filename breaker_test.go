@@ -0,0 +1,166 @@
+package healthchecks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	type transition struct {
+		from, to CircuitState
+	}
+	var transitions []transition
+	cb, err := NewCircuitBreaker(2, time.Minute, func(from, to CircuitState) {
+		transitions = append(transitions, transition{from: from, to: to})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	errTransport := errors.New("connection refused")
+	send := func(err error) error {
+		record, acquireErr := cb.acquire()
+		if acquireErr != nil {
+			return acquireErr
+		}
+		record(ctx, err)
+		return nil
+	}
+
+	steps := []struct {
+		name      string
+		advance   time.Duration
+		err       error
+		wantErr   error
+		wantState CircuitState
+	}{
+		{name: "first failure", err: errTransport, wantState: CircuitClosed},
+		{name: "success resets", wantState: CircuitClosed},
+		{name: "failure", err: errTransport, wantState: CircuitClosed},
+		{name: "threshold reached", err: errTransport, wantState: CircuitOpen},
+		{name: "fails fast", advance: 30 * time.Second, wantErr: ErrCircuitOpen, wantState: CircuitOpen},
+		{name: "failed probe", advance: 30 * time.Second, err: errTransport, wantState: CircuitOpen},
+		{name: "fails fast again", advance: 59 * time.Second, wantErr: ErrCircuitOpen, wantState: CircuitOpen},
+		{name: "successful probe", advance: time.Second, wantState: CircuitClosed},
+	}
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		if err := send(step.err); !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: error = %v, want %v", step.name, err, step.wantErr)
+		}
+		if got := cb.State(); got != step.wantState {
+			t.Fatalf("%s: State() = %s, want %s", step.name, got, step.wantState)
+		}
+	}
+
+	want := []transition{
+		{from: CircuitClosed, to: CircuitOpen},
+		{from: CircuitOpen, to: CircuitHalfOpen},
+		{from: CircuitHalfOpen, to: CircuitOpen},
+		{from: CircuitOpen, to: CircuitHalfOpen},
+		{from: CircuitHalfOpen, to: CircuitClosed},
+	}
+	if !reflect.DeepEqual(transitions, want) {
+		t.Errorf("transitions = %v, want %v", transitions, want)
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	cb, err := NewCircuitBreaker(1, time.Minute, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb.now = func() time.Time { return now }
+
+	record, err := cb.acquire()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record(context.Background(), errors.New("timeout"))
+	now = now.Add(time.Minute)
+
+	probe, err := cb.acquire()
+	if err != nil {
+		t.Fatalf("acquire() error = %v, want probe", err)
+	}
+	if _, err := cb.acquire(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("acquire() during probe error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	// a probe cancelled by the caller allows another probe
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	probe(cancelled, context.Canceled)
+	if got := cb.State(); got != CircuitHalfOpen {
+		t.Errorf("State() = %s, want %s", got, CircuitHalfOpen)
+	}
+	if _, err := cb.acquire(); err != nil {
+		t.Errorf("acquire() after cancelled probe error = %v, want nil", err)
+	}
+}
+
+func TestNewCircuitBreaker(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		cooldown  time.Duration
+		wantErr   bool
+	}{
+		{name: "valid", threshold: 3, cooldown: time.Minute},
+		{name: "zero threshold", threshold: 0, cooldown: time.Minute, wantErr: true},
+		{name: "zero cooldown", threshold: 3, cooldown: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCircuitBreaker(tt.threshold, tt.cooldown, nil); (err != nil) != tt.wantErr {
+				t.Errorf("NewCircuitBreaker() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer srv.Close()
+
+	cb, err := NewCircuitBreaker(2, time.Hour, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	project, err := NewProject(testRedactPingKey, WithURL(srv.URL), WithTimeout(10*time.Millisecond), WithCircuitBreaker(cb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for _, slug := range []string{"foo", "bar"} {
+		if err := project.Success(ctx, slug); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Success(%s) error = %v, want timeout", slug, err)
+		}
+	}
+	// the breaker is shared by all checks of the project
+	if err := project.Slug("baz").Success(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Success() error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+
+	if _, err := NewProject(testRedactPingKey, WithCircuitBreaker(nil)); err == nil {
+		t.Error("NewProject() error = nil, want error for nil circuit breaker")
+	}
+}
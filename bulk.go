@@ -0,0 +1,84 @@
+package healthchecks
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+const defaultConcurrency = 8
+
+// SlugSignal is a signal sent to a check of a [Project] via [Project.SignalMany].
+type SlugSignal struct {
+	// Slug identifies the check.
+	Slug string
+	// Signal is sent to the check.
+	Signal Signal
+	// Body is attached to the signal, e.g. the message of [SignalLog]. It is optional.
+	Body string
+}
+
+// SignalMany sends multiple signals to checks of this [Project] concurrently.
+//
+// At most 8 signals are in flight at the same time by default, see [WithConcurrency].
+// Connections are shared between all signals.
+//
+// The result contains an entry for every slug, which is nil if all signals for that slug were sent successfully.
+// If the context is cancelled, no further signals are sent and the remaining slugs report the context's error.
+func (p *Project) SignalMany(ctx context.Context, signals []SlugSignal) map[string]error {
+	results := make(map[string]error, len(signals))
+	var mu sync.Mutex
+	record := func(slug string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		// join errors if a slug is signalled multiple times
+		results[slug] = errors.Join(results[slug], err)
+	}
+
+	concurrency := p.opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, s := range signals {
+		if !acquireSlot(ctx, sem) {
+			for _, remaining := range signals[i:] {
+				record(remaining.Slug, ctx.Err())
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func(s SlugSignal) {
+			defer wg.Done()
+			defer func() {
+				<-sem
+			}()
+			record(s.Slug, p.send(ctx, s))
+		}(s)
+	}
+	wg.Wait()
+	return results
+}
+
+// acquireSlot blocks until a slot in sem is available, reporting false if ctx is done first.
+func acquireSlot(ctx context.Context, sem chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case sem <- struct{}{}:
+		return true
+	}
+}
+
+func (p *Project) send(ctx context.Context, s SlugSignal) error {
+	if s.Body != "" || s.Signal == SignalLog {
+		return p.Send(ctx, s.Slug, s.Signal, WithBody(s.Body))
+	}
+	return p.Send(ctx, s.Slug, s.Signal)
+}
@@ -0,0 +1,98 @@
+package healthchecks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProjectSignalMany(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	var mu sync.Mutex
+	received := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		b, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received[r.URL.Path] = string(b)
+		mu.Unlock()
+
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			_, _ = io.WriteString(w, "OK (not found)")
+			return
+		}
+		_, _ = io.WriteString(w, "OK")
+	}))
+	defer server.Close()
+
+	p, err := NewProject("rk9bbOJREu6nOWeHGjlnDQ", WithURL(server.URL), WithConcurrency(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := p.SignalMany(context.Background(), []SlugSignal{
+		{Slug: "a", Signal: SignalSuccess},
+		{Slug: "b", Signal: SignalFail},
+		{Slug: "c", Signal: SignalLog, Body: "foo"},
+		{Slug: "d", Signal: SignalExitStatus(3)},
+		{Slug: "missing", Signal: SignalSuccess},
+		{Slug: "Invalid", Signal: SignalSuccess},
+	})
+
+	for _, slug := range []string{"a", "b", "c", "d"} {
+		if err, ok := got[slug]; !ok || err != nil {
+			t.Errorf("SignalMany()[%s] = %v (present: %v), want nil", slug, err, ok)
+		}
+	}
+	if got["missing"] == nil {
+		t.Errorf("SignalMany()[missing] = nil, want error")
+	}
+	if !errors.Is(got["Invalid"], ErrInvalidSlug) {
+		t.Errorf("SignalMany()[Invalid] = %v, want %v", got["Invalid"], ErrInvalidSlug)
+	}
+	if m := maxInFlight.Load(); m > 2 {
+		t.Errorf("%d requests in flight, want <= 2", m)
+	}
+	if body := received["/rk9bbOJREu6nOWeHGjlnDQ/c/log"]; body != "foo" {
+		t.Errorf("log body = %q, want %q", body, "foo")
+	}
+	if _, ok := received["/rk9bbOJREu6nOWeHGjlnDQ/d/3"]; !ok {
+		t.Errorf("exit status not received, got %v", received)
+	}
+}
+
+func TestProjectSignalManyCancelled(t *testing.T) {
+	p, err := NewProject("rk9bbOJREu6nOWeHGjlnDQ", WithURL("http://127.0.0.1:1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	got := p.SignalMany(ctx, []SlugSignal{
+		{Slug: "a", Signal: SignalSuccess},
+		{Slug: "b", Signal: SignalSuccess},
+	})
+	for _, slug := range []string{"a", "b"} {
+		if !errors.Is(got[slug], context.Canceled) {
+			t.Errorf("SignalMany()[%s] = %v, want %v", slug, got[slug], context.Canceled)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/stnokott/healthchecks/scheduler"
+)
+
+// agent runs the monitors of a configuration file, replacing them when the file is reloaded.
+type agent struct {
+	configPath      string
+	shutdownTimeout time.Duration
+	logger          *slog.Logger
+
+	sched *scheduler.Scheduler
+}
+
+func runAgent(ctx context.Context, args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("agent", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(fs.Output(), "Usage: hc agent [flags]\n\n"+
+			"Runs the monitors defined in the configuration file until interrupted.\n"+
+			"Send SIGHUP to reload the configuration file.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	configPath := fs.String("config", "/etc/hc/agent.yaml", "path to the configuration file")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 30*time.Second, "maximum time to wait for running probes when stopping or reloading")
+	newLogger := logFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	logger, err := newLogger(stderr)
+	if err != nil {
+		return err
+	}
+
+	a := &agent{
+		configPath:      *configPath,
+		shutdownTimeout: *shutdownTimeout,
+		logger:          logger,
+	}
+	if err := a.reload(); err != nil {
+		return err
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("stopping")
+			a.stop()
+			return nil
+		case <-hup:
+			logger.Info("reloading configuration", "path", a.configPath)
+			if err := a.reload(); err != nil {
+				logger.Error("reloading configuration failed, keeping the previous one", "error", err)
+			}
+		}
+	}
+}
+
+// reload loads the configuration file and replaces the running monitors.
+//
+// If the configuration file is invalid, the running monitors are kept.
+func (a *agent) reload() error {
+	cfg, err := loadConfig(a.configPath)
+	if err != nil {
+		return err
+	}
+	sched, err := cfg.newScheduler(scheduler.WithLogger(a.logger))
+	if err != nil {
+		return err
+	}
+
+	a.stop()
+	a.sched = sched
+	sched.Start()
+	for _, j := range sched.Jobs() {
+		a.logger.Info("monitor started", "monitor", j.Name(), "next", j.Next())
+	}
+	return nil
+}
+
+// stop stops the running monitors, waiting for running probes up to the shutdown timeout.
+func (a *agent) stop() {
+	if a.sched == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+	defer cancel()
+	if err := a.sched.Shutdown(ctx); err != nil {
+		a.logger.Warn("probes still running after shutdown timeout", "error", err)
+	}
+	a.sched = nil
+}
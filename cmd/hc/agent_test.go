@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pingRecorder is a ping endpoint recording the paths of all requests.
+type pingRecorder struct {
+	mu    sync.Mutex
+	paths []string
+}
+
+func (p *pingRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.paths = append(p.paths, r.URL.Path)
+	p.mu.Unlock()
+	_, _ = io.WriteString(w, "OK")
+}
+
+func (p *pingRecorder) has(path string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, got := range p.paths {
+		if got == path {
+			return true
+		}
+	}
+	return false
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func agentConfigYAML(url, slug, file string) string {
+	return `
+defaults:
+  url: ` + url + `
+monitors:
+  - name: ` + slug + `
+    interval: 20ms
+    probe:
+      file:
+        path: ` + file + `
+        max_age: 1h
+    check:
+      ping_key: ` + testPingKey + `
+      slug: ` + slug + `
+`
+}
+
+func TestAgentReload(t *testing.T) {
+	rec := &pingRecorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	dir := t.TempDir()
+	fresh := filepath.Join(dir, "fresh")
+	if err := os.WriteFile(fresh, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	configPath := writeConfig(t, agentConfigYAML(srv.URL, "first", fresh))
+
+	a := &agent{
+		configPath:      configPath,
+		shutdownTimeout: time.Second,
+		logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	if err := a.reload(); err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+	defer a.stop()
+	waitFor(t, func() bool {
+		return rec.has("/"+testPingKey+"/first/start") && rec.has("/"+testPingKey+"/first")
+	})
+
+	// an invalid configuration keeps the previous monitors
+	if err := os.WriteFile(configPath, []byte("monitors: []"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.reload(); err == nil {
+		t.Fatal("reload() with invalid config error = nil, want error")
+	}
+	if jobs := a.sched.Jobs(); len(jobs) != 1 || jobs[0].Name() != "first" {
+		t.Fatalf("jobs after failed reload = %v, want [first]", jobs)
+	}
+
+	// a missing file fails the probe
+	if err := os.WriteFile(configPath, []byte(agentConfigYAML(srv.URL, "second", filepath.Join(dir, "missing"))), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.reload(); err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+	waitFor(t, func() bool {
+		return rec.has("/" + testPingKey + "/second/fail")
+	})
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStderr string
+	}{
+		{name: "no command", args: nil, wantCode: 2, wantStderr: "Usage: hc <command>"},
+		{name: "unknown command", args: []string{"foo"}, wantCode: 2, wantStderr: "unknown command 'foo'"},
+		{name: "help", args: []string{"agent", "-h"}, wantCode: 0, wantStderr: "Usage: hc agent"},
+		{name: "missing config", args: []string{"agent", "-config", "/nonexistent.yaml"}, wantCode: 1, wantStderr: "no such file"},
+		{name: "invalid log format", args: []string{"agent", "-log-format", "xml"}, wantCode: 1, wantStderr: "invalid log format 'xml'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			if code := run(context.Background(), tt.args, &stderr); code != tt.wantCode {
+				t.Errorf("run() = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stnokott/healthchecks"
+	"github.com/stnokott/healthchecks/probe"
+	"github.com/stnokott/healthchecks/scheduler"
+)
+
+// agentConfig is the configuration file of the agent.
+type agentConfig struct {
+	Defaults defaultsConfig  `yaml:"defaults"`
+	Monitors []monitorConfig `yaml:"monitors"`
+}
+
+// defaultsConfig applies to all monitors.
+type defaultsConfig struct {
+	// URL is the root URL of the ping endpoint, defaults to https://hc-ping.com.
+	URL string `yaml:"url"`
+	// Timeout limits sending a single signal.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// monitorConfig is a single monitor, running a probe and reporting its result to a check.
+type monitorConfig struct {
+	Name string `yaml:"name"`
+	// Either Interval or Schedule (a cron expression evaluated in Timezone) is required.
+	Interval time.Duration `yaml:"interval"`
+	Schedule string        `yaml:"schedule"`
+	Timezone string        `yaml:"timezone"`
+	Jitter   time.Duration `yaml:"jitter"`
+	// Timeout limits the probe, defaults to the interval or one minute for schedules.
+	Timeout time.Duration `yaml:"timeout"`
+	Probe   probeConfig   `yaml:"probe"`
+	// Check is a UUID, ping URL, DSN or an object, see [healthchecks.Check.UnmarshalYAML].
+	Check healthchecks.CheckConfig `yaml:"check"`
+}
+
+// probeConfig requires exactly one field to be set.
+type probeConfig struct {
+	Command []string    `yaml:"command"`
+	HTTP    string      `yaml:"http"`
+	TCP     string      `yaml:"tcp"`
+	File    *fileConfig `yaml:"file"`
+}
+
+type fileConfig struct {
+	Path   string        `yaml:"path"`
+	MaxAge time.Duration `yaml:"max_age"`
+}
+
+// defaultScheduleTimeout is the probe timeout of monitors with a cron schedule.
+const defaultScheduleTimeout = time.Minute
+
+// loadConfig reads and validates the configuration file at path.
+func loadConfig(path string) (*agentConfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	var cfg agentConfig
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(cfg.Monitors) == 0 {
+		return nil, errors.New("no monitors configured")
+	}
+	return &cfg, nil
+}
+
+// newScheduler creates a scheduler with a job for each monitor.
+func (cfg *agentConfig) newScheduler(opts ...scheduler.Option) (*scheduler.Scheduler, error) {
+	s, err := scheduler.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var checkOpts []healthchecks.Option
+	if cfg.Defaults.URL != "" {
+		checkOpts = append(checkOpts, healthchecks.WithURL(cfg.Defaults.URL))
+	}
+	if cfg.Defaults.Timeout != 0 {
+		checkOpts = append(checkOpts, healthchecks.WithTimeout(cfg.Defaults.Timeout))
+	}
+	for i, m := range cfg.Monitors {
+		if m.Name == "" {
+			return nil, fmt.Errorf("monitor #%d: name must not be empty", i+1)
+		}
+		if err := m.add(s, checkOpts); err != nil {
+			return nil, fmt.Errorf("monitor '%s': %w", m.Name, err)
+		}
+	}
+	return s, nil
+}
+
+func (m monitorConfig) add(s *scheduler.Scheduler, checkOpts []healthchecks.Option) error {
+	schedule, timeout, err := m.schedule()
+	if err != nil {
+		return err
+	}
+	checker, err := m.Probe.checker()
+	if err != nil {
+		return fmt.Errorf("probe: %w", err)
+	}
+	n, err := m.Check.Check(checkOpts...)
+	if err != nil {
+		return fmt.Errorf("check: %w", err)
+	}
+
+	jobOpts := []scheduler.JobOption{scheduler.WithJobTimeout(timeout)}
+	if m.Jitter != 0 {
+		jobOpts = append(jobOpts, scheduler.WithJitter(m.Jitter))
+	}
+	_, err = s.Add(m.Name, schedule, n, checker.Check, jobOpts...)
+	return err
+}
+
+// schedule returns the schedule of the monitor and the timeout of its probe.
+func (m monitorConfig) schedule() (scheduler.Schedule, time.Duration, error) {
+	switch {
+	case m.Interval != 0 && m.Schedule != "":
+		return nil, 0, errors.New("only one of interval and schedule may be set")
+	case m.Interval < 0:
+		return nil, 0, fmt.Errorf("interval is %s, needs to be > 0", m.Interval)
+	case m.Interval > 0:
+		if m.Timezone != "" {
+			return nil, 0, errors.New("timezone requires a schedule")
+		}
+		return scheduler.Every(m.Interval), m.timeout(m.Interval), nil
+	case m.Schedule != "":
+		loc, err := time.LoadLocation(m.Timezone)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid timezone: %w", err)
+		}
+		cron, err := scheduler.ParseCron(m.Schedule, loc)
+		if err != nil {
+			return nil, 0, err
+		}
+		return cron, m.timeout(defaultScheduleTimeout), nil
+	default:
+		return nil, 0, errors.New("either interval or schedule is required")
+	}
+}
+
+func (m monitorConfig) timeout(fallback time.Duration) time.Duration {
+	if m.Timeout > 0 {
+		return m.Timeout
+	}
+	return fallback
+}
+
+func (p probeConfig) checker() (probe.Checker, error) {
+	var checkers []probe.Checker
+	if len(p.Command) > 0 {
+		checkers = append(checkers, probe.Command(p.Command[0], p.Command[1:]...))
+	}
+	if p.HTTP != "" {
+		checkers = append(checkers, probe.HTTPGet(p.HTTP, http.DefaultClient))
+	}
+	if p.TCP != "" {
+		checkers = append(checkers, probe.TCPDial(p.TCP))
+	}
+	if p.File != nil {
+		if p.File.Path == "" || p.File.MaxAge <= 0 {
+			return nil, errors.New("file requires path and a positive max_age")
+		}
+		checkers = append(checkers, probe.FileFreshness(p.File.Path, p.File.MaxAge))
+	}
+	if len(checkers) != 1 {
+		return nil, errors.New("exactly one of command, http, tcp and file is required")
+	}
+	return checkers[0], nil
+}
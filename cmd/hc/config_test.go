@@ -0,0 +1,193 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stnokott/healthchecks"
+)
+
+const (
+	testUUID    = "6da9bc25-880d-4a73-a0e5-e833405e206f"
+	testPingKey = "rk9bbOJREu6nOWeHGjlnDQ"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "agent.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeConfig(t, `
+defaults:
+  url: https://hc.example.com
+  timeout: 5s
+monitors:
+  - name: backup
+    schedule: "30 2 * * *"
+    timezone: Europe/Berlin
+    probe:
+      file:
+        path: /backup/latest.tar
+        max_age: 25h
+    check:
+      uuid: `+testUUID+`
+  - name: api
+    interval: 1m
+    jitter: 10s
+    probe:
+      http: http://localhost:8080/healthz
+    check:
+      ping_key: `+testPingKey+`
+      slug: api
+`)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	want := &agentConfig{
+		Defaults: defaultsConfig{URL: "https://hc.example.com", Timeout: 5 * time.Second},
+		Monitors: []monitorConfig{
+			{
+				Name:     "backup",
+				Schedule: "30 2 * * *",
+				Timezone: "Europe/Berlin",
+				Probe:    probeConfig{File: &fileConfig{Path: "/backup/latest.tar", MaxAge: 25 * time.Hour}},
+				Check:    healthchecks.CheckConfig{UUID: testUUID},
+			},
+			{
+				Name:     "api",
+				Interval: time.Minute,
+				Jitter:   10 * time.Second,
+				Probe:    probeConfig{HTTP: "http://localhost:8080/healthz"},
+				Check:    healthchecks.CheckConfig{PingKey: testPingKey, Slug: "api"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("loadConfig() = %+v, want %+v", cfg, want)
+	}
+
+	s, err := cfg.newScheduler()
+	if err != nil {
+		t.Fatalf("newScheduler() error = %v", err)
+	}
+	var names []string
+	for _, j := range s.Jobs() {
+		names = append(names, j.Name())
+	}
+	if want := []string{"api", "backup"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("jobs = %v, want %v", names, want)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "no monitors",
+			content: "monitors: []",
+			wantErr: "no monitors configured",
+		},
+		{
+			name:    "unknown field",
+			content: "monitors:\n  - name: a\n    intervall: 1m",
+			wantErr: "field intervall not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadConfig(writeConfig(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("loadConfig() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSchedulerInvalid(t *testing.T) {
+	valid := monitorConfig{
+		Name:     "a",
+		Interval: time.Minute,
+		Probe:    probeConfig{TCP: "localhost:22"},
+		Check:    healthchecks.CheckConfig{UUID: testUUID},
+	}
+	tests := []struct {
+		name    string
+		modify  func(m *monitorConfig)
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			modify:  func(m *monitorConfig) { m.Name = "" },
+			wantErr: "name must not be empty",
+		},
+		{
+			name:    "missing schedule",
+			modify:  func(m *monitorConfig) { m.Interval = 0 },
+			wantErr: "either interval or schedule is required",
+		},
+		{
+			name:    "interval and schedule",
+			modify:  func(m *monitorConfig) { m.Schedule = "* * * * *" },
+			wantErr: "only one of interval and schedule may be set",
+		},
+		{
+			name:    "invalid cron",
+			modify:  func(m *monitorConfig) { m.Interval, m.Schedule = 0, "* * *" },
+			wantErr: "has 3 fields",
+		},
+		{
+			name:    "invalid timezone",
+			modify:  func(m *monitorConfig) { m.Interval, m.Schedule, m.Timezone = 0, "* * * * *", "Mars/Olympus" },
+			wantErr: "invalid timezone",
+		},
+		{
+			name:    "two probes",
+			modify:  func(m *monitorConfig) { m.Probe.HTTP = "http://localhost" },
+			wantErr: "exactly one of command, http, tcp and file is required",
+		},
+		{
+			name:    "no probe",
+			modify:  func(m *monitorConfig) { m.Probe = probeConfig{} },
+			wantErr: "exactly one of command, http, tcp and file is required",
+		},
+		{
+			name:    "uuid and slug",
+			modify:  func(m *monitorConfig) { m.Check.Slug = "a" },
+			wantErr: "either uuid or ping_key and slug are required",
+		},
+		{
+			name:    "slug without ping key",
+			modify:  func(m *monitorConfig) { m.Check = healthchecks.CheckConfig{Slug: "a"} },
+			wantErr: "either uuid or ping_key and slug are required",
+		},
+		{
+			name:    "invalid uuid",
+			modify:  func(m *monitorConfig) { m.Check.UUID = "foo" },
+			wantErr: "invalid UUID",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid
+			tt.modify(&m)
+			cfg := &agentConfig{Monitors: []monitorConfig{m}}
+			_, err := cfg.newScheduler()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("newScheduler() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/stnokott/healthchecks/exporter"
+)
+
+func runExporter(ctx context.Context, args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("exporter", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(fs.Output(), "Usage: hc exporter [flags]\n\n"+
+			"Polls the checks of a project and serves their status as Prometheus metrics on /metrics.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	listen := fs.String("listen", ":9811", "address to serve the metrics on")
+	newClient := apiFlags(fs)
+	interval := fs.Duration("interval", time.Minute, "interval for polling the management API")
+	var tags listFlag
+	fs.Var(&tags, "tag", "only expose checks with this tag, can be given multiple times")
+	newLogger := logFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	logger, err := newLogger(stderr)
+	if err != nil {
+		return err
+	}
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	collector, err := exporter.NewCollector(client,
+		exporter.WithInterval(*interval),
+		exporter.WithTags(tags...),
+		exporter.WithLogger(logger),
+	)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", *listen)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", collector.Handler())
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	pollCtx, stopPolling := context.WithCancel(ctx)
+	defer stopPolling()
+	go collector.Run(pollCtx)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ln)
+	}()
+	logger.Info("serving metrics", "address", ln.Addr().String())
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	logger.Info("stopping")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
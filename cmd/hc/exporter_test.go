@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExporter(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "apikey" {
+			http.Error(w, "wrong api key", http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(w, `{"checks": [{"name": "Backup", "slug": "backup", "uuid": "`+testUUID+`", "status": "up", "n_pings": 3}]}`)
+	}))
+	defer api.Close()
+
+	// reserve a free port
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	t.Setenv(apiKeyEnv, "apikey")
+	ctx, cancel := context.WithCancel(context.Background())
+	var stderr bytes.Buffer
+	done := make(chan int)
+	go func() {
+		done <- run(ctx, []string{"exporter", "-listen", addr, "-api-url", api.URL, "-tag", "prod"}, &stderr)
+	}()
+
+	want := `healthchecks_check_pings_total{id="82fe8f4a6c34a8f02e4d0afed71360b33138dff8",name="Backup",slug="backup",tags=""} 3`
+	waitFor(t, func() bool {
+		resp, err := http.Get("http://" + addr + "/metrics")
+		if err != nil {
+			return false
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		return err == nil && strings.Contains(string(body), want)
+	})
+
+	cancel()
+	if code := <-done; code != 0 {
+		t.Errorf("run() = %d, want 0, stderr: %s", code, stderr.String())
+	}
+}
+
+func TestExporterMissingAPIKey(t *testing.T) {
+	t.Setenv(apiKeyEnv, "")
+	var stderr bytes.Buffer
+	if code := run(context.Background(), []string{"exporter"}, &stderr); code != 1 {
+		t.Errorf("run() = %d, want 1", code)
+	}
+	if want := "-api-key or " + apiKeyEnv + " is required"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr = %q, want to contain %q", stderr.String(), want)
+	}
+}
@@ -0,0 +1,145 @@
+// Command hc provides tools around healthchecks.io.
+//
+// Usage:
+//
+//	hc agent [flags]                         run monitors defined in a configuration file
+//	hc exporter [flags]                      serve the status of checks as Prometheus metrics
+//	hc report [flags]                        compute uptime figures of checks
+//	hc sdnotify [flags] -- command [args]    run a command, translating its sd_notify messages into signals (unix only)
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/stnokott/healthchecks"
+)
+
+const usage = `Usage: hc <command> [flags]
+
+Commands:
+  agent       run monitors defined in a configuration file
+  exporter    serve the status of checks as Prometheus metrics
+  report      compute uptime figures of checks
+  sdnotify    run a command, translating its sd_notify messages into signals (unix only)
+
+Run "hc <command> -h" for the flags of a command.
+`
+
+// command runs a subcommand with its arguments, returning once ctx is done at the latest.
+type command func(ctx context.Context, args []string, stderr io.Writer) error
+
+var commands = map[string]command{
+	"agent":    runAgent,
+	"exporter": runExporter,
+	"report":   runReport,
+}
+
+// apiKeyEnv is the environment variable holding the API key if -api-key is not given.
+const apiKeyEnv = "HC_API_KEY"
+
+// exitCode makes hc exit with the code without printing an error, e.g. to forward the exit code of a wrapped command.
+type exitCode int
+
+func (c exitCode) Error() string {
+	return fmt.Sprintf("exit code %d", int(c))
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+		return 2
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "unknown command '%s'\n\n%s", args[0], usage)
+		return 2
+	}
+	if err := cmd(ctx, args[1:], stderr); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		var code exitCode
+		if errors.As(err, &code) {
+			return int(code)
+		}
+		_, _ = fmt.Fprintf(stderr, "hc %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+// logFlags registers the flags configuring the logger on fs.
+//
+// The returned function creates the logger after fs has been parsed.
+func logFlags(fs *flag.FlagSet) func(w io.Writer) (*slog.Logger, error) {
+	format := fs.String("log-format", "text", "log format, one of text or json")
+	level := fs.String("log-level", "info", "minimum log level, one of debug, info, warn or error")
+	return func(w io.Writer) (*slog.Logger, error) {
+		var lvl slog.Level
+		if err := lvl.UnmarshalText([]byte(*level)); err != nil {
+			return nil, fmt.Errorf("invalid log level '%s'", *level)
+		}
+		handlerOpts := &slog.HandlerOptions{Level: lvl}
+		switch strings.ToLower(*format) {
+		case "text":
+			return slog.New(slog.NewTextHandler(w, handlerOpts)), nil
+		case "json":
+			return slog.New(slog.NewJSONHandler(w, handlerOpts)), nil
+		default:
+			return nil, fmt.Errorf("invalid log format '%s'", *format)
+		}
+	}
+}
+
+// apiFlags registers the flags configuring the management API client on fs.
+//
+// The returned function creates the client after fs has been parsed.
+func apiFlags(fs *flag.FlagSet) func() (*healthchecks.APIClient, error) {
+	apiKey := fs.String("api-key", "", "API key of the project, read-only keys are sufficient (default $"+apiKeyEnv+")")
+	pingURL := fs.String("url", "", "ping URL of a self-hosted instance, used for deriving the API URL")
+	apiURL := fs.String("api-url", "", "URL of the management API, if it can't be derived from -url")
+	return func() (*healthchecks.APIClient, error) {
+		key := *apiKey
+		if key == "" {
+			key = os.Getenv(apiKeyEnv)
+		}
+		if key == "" {
+			return nil, errors.New("-api-key or " + apiKeyEnv + " is required")
+		}
+		var opts []healthchecks.Option
+		if *pingURL != "" {
+			opts = append(opts, healthchecks.WithURL(*pingURL))
+		}
+		if *apiURL != "" {
+			opts = append(opts, healthchecks.WithAPIURL(*apiURL))
+		}
+		return healthchecks.NewAPIClient(key, opts...)
+	}
+}
+
+// listFlag is a flag which can be given multiple times.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stnokott/healthchecks/report"
+)
+
+// stdout is where reports are written to, replaced in tests.
+var stdout io.Writer = os.Stdout
+
+func runReport(ctx context.Context, args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(fs.Output(), "Usage: hc report [flags]\n\n"+
+			"Computes uptime, outages, MTTR and the availability per day of the checks of a project from their flip history.\n"+
+			"Times are either relative to now (e.g. 30d, 12h) or absolute (e.g. 2024-01-01, 2024-01-01T12:00:00Z).\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	since := fs.String("since", "30d", "start of the time range")
+	until := fs.String("until", "", "end of the time range (default now)")
+	format := fs.String("format", "markdown", "output format, one of markdown, json or csv")
+	timezone := fs.String("timezone", "UTC", "time zone of absolute times and days")
+	var tags listFlag
+	fs.Var(&tags, "tag", "only include checks with this tag, can be given multiple times")
+	newClient := apiFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	loc, err := time.LoadLocation(*timezone)
+	if err != nil {
+		return fmt.Errorf("invalid time zone: %w", err)
+	}
+	now := time.Now()
+	from, err := parseReportTime(*since, now, loc)
+	if err != nil {
+		return fmt.Errorf("invalid -since: %w", err)
+	}
+	to := now
+	if *until != "" {
+		if to, err = parseReportTime(*until, now, loc); err != nil {
+			return fmt.Errorf("invalid -until: %w", err)
+		}
+	}
+	var write func(r *report.Report, w io.Writer) error
+	switch strings.ToLower(*format) {
+	case "markdown", "md":
+		write = (*report.Report).WriteMarkdown
+	case "json":
+		write = (*report.Report).WriteJSON
+	case "csv":
+		write = (*report.Report).WriteCSV
+	default:
+		return fmt.Errorf("invalid format '%s'", *format)
+	}
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	r, err := report.Generate(ctx, client, from, to, report.WithTags(tags...), report.WithLocation(loc))
+	if err != nil {
+		return err
+	}
+	return write(r, stdout)
+}
+
+// parseReportTime parses s either as duration before now, supporting days as "d" unit, or as absolute time in loc.
+func parseReportTime(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("'%s' is neither a duration nor a time", s)
+}
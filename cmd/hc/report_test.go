@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseReportTime(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	tests := []struct {
+		s       string
+		want    time.Time
+		wantErr bool
+	}{
+		{s: "30d", want: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{s: "12h", want: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{s: "2024-01-01", want: time.Date(2024, 1, 1, 0, 0, 0, 0, berlin)},
+		{s: "2024-01-01T06:00:00", want: time.Date(2024, 1, 1, 6, 0, 0, 0, berlin)},
+		{s: "2024-01-01T06:00:00Z", want: time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC)},
+		{s: "-3d", wantErr: true},
+		{s: "last month", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := parseReportTime(tt.s, now, berlin)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseReportTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseReportTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/checks/":
+			_, _ = io.WriteString(w, `{"checks": [{"name": "Backup", "uuid": "`+testUUID+`", "status": "up", "tags": "prod"}]}`)
+		case "/api/v3/checks/" + testUUID + "/flips/":
+			_, _ = io.WriteString(w, `[{"timestamp": "2023-12-01T00:00:00Z", "up": 1}, {"timestamp": "2024-01-01T18:00:00Z", "up": 0},
+				{"timestamp": "2024-01-02T00:00:00Z", "up": 1}]`)
+		case "/api/v3/checks/" + testUUID + "/pings/":
+			_, _ = io.WriteString(w, `{"pings": [{"type": "fail", "date": "2024-01-01T18:00:00Z"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer api.Close()
+
+	var out bytes.Buffer
+	oldStdout := stdout
+	stdout = &out
+	defer func() { stdout = oldStdout }()
+
+	var stderr bytes.Buffer
+	args := []string{"report", "-api-key", "apikey", "-api-url", api.URL, "-since", "2024-01-01", "-until", "2024-01-03", "-format", "csv", "-tag", "prod"}
+	if code := run(context.Background(), args, &stderr); code != 0 {
+		t.Fatalf("run() = %d, want 0, stderr: %s", code, stderr.String())
+	}
+	want := "Backup,,82fe8f4a6c34a8f02e4d0afed71360b33138dff8,prod,87.500,172800,21600,1,21600,21600,75.000,100.000\n"
+	if !strings.HasSuffix(out.String(), want) {
+		t.Errorf("output = %q, want suffix %q", out.String(), want)
+	}
+
+	stderr.Reset()
+	args = []string{"report", "-api-key", "apikey", "-format", "xlsx"}
+	if code := run(context.Background(), args, &stderr); code != 1 || !strings.Contains(stderr.String(), "invalid format 'xlsx'") {
+		t.Errorf("run() with invalid format = %d, stderr: %s", code, stderr.String())
+	}
+}
@@ -0,0 +1,70 @@
+//go:build unix
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/stnokott/healthchecks"
+	"github.com/stnokott/healthchecks/sdnotify"
+)
+
+func init() {
+	commands["sdnotify"] = runSDNotify
+}
+
+func runSDNotify(ctx context.Context, args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("sdnotify", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(fs.Output(), "Usage: hc sdnotify [flags] -- command [args]\n\n"+
+			"Runs the command with NOTIFY_SOCKET set and translates its sd_notify messages into signals.\n"+
+			"Exits with the exit code of the command.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	pingURL := fs.String("url", "", "ping URL or DSN of the check (required)")
+	stopTimeout := fs.Duration("stop-timeout", 30*time.Second, "time to wait for the command to exit after SIGTERM before killing it")
+	newLogger := logFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	logger, err := newLogger(stderr)
+	if err != nil {
+		return err
+	}
+	if *pingURL == "" {
+		return errors.New("-url is required")
+	}
+	if fs.NArg() == 0 {
+		return errors.New("missing command")
+	}
+
+	n, err := healthchecks.FromURL(*pingURL)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, fs.Arg(0), fs.Args()[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, stderr
+	// let the command shut down gracefully when hc is interrupted
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = *stopTimeout
+
+	code, err := sdnotify.Run(ctx, n, cmd, sdnotify.WithLogger(logger))
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return exitCode(code)
+	}
+	return nil
+}
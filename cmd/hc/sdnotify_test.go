@@ -0,0 +1,32 @@
+//go:build unix
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSDNotify(t *testing.T) {
+	rec := &pingRecorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	var stderr bytes.Buffer
+	args := []string{"sdnotify", "-url", srv.URL + "/" + testUUID, "--", "sh", "-c", "exit 3"}
+	if code := run(context.Background(), args, &stderr); code != 3 {
+		t.Errorf("run() = %d, want 3, stderr: %s", code, stderr.String())
+	}
+	for _, path := range []string{"/" + testUUID + "/start", "/" + testUUID + "/3"} {
+		if !rec.has(path) {
+			t.Errorf("missing request to %s", path)
+		}
+	}
+
+	stderr.Reset()
+	if code := run(context.Background(), []string{"sdnotify", "-url", srv.URL + "/" + testUUID}, &stderr); code != 1 {
+		t.Errorf("run() without command = %d, want 1", code)
+	}
+}
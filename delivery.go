@@ -0,0 +1,32 @@
+package healthchecks
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// deliveryContext returns the context and body used for sending sig.
+//
+// If guaranteed delivery is enabled (see [WithGuaranteedDelivery]), terminal signals are sent using a context which
+// is detached from the cancellation of ctx and bounded by the grace timeout instead.
+// If ctx has already ended, its cause is appended to the body so the check records why the job was aborted.
+//
+// The returned [context.CancelFunc] needs to be called once the request is done.
+func deliveryContext(ctx context.Context, opts *options, sig Signal, body io.Reader) (context.Context, io.Reader, context.CancelFunc) {
+	if opts.GracePeriod <= 0 || !sig.terminal() {
+		return ctx, body, func() {}
+	}
+
+	if ctx.Err() != nil {
+		note := "signal sent after the caller's context ended: " + context.Cause(ctx).Error()
+		if body == nil {
+			body = strings.NewReader(note)
+		} else {
+			body = io.MultiReader(body, strings.NewReader("\n\n"+note))
+		}
+	}
+
+	detached, cancel := context.WithTimeout(context.WithoutCancel(ctx), opts.GracePeriod)
+	return detached, body, cancel
+}
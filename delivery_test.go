@@ -0,0 +1,75 @@
+package healthchecks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGuaranteedDelivery(t *testing.T) {
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		_, _ = io.WriteString(w, "OK")
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		sig      Signal
+		wantErr  bool
+		wantBody string
+	}{
+		{
+			name:     "fail with guaranteed delivery",
+			opts:     []Option{WithGuaranteedDelivery(time.Second)},
+			sig:      SignalFail,
+			wantErr:  false,
+			wantBody: "signal sent after the caller's context ended: context canceled",
+		},
+		{
+			name:     "exit status with guaranteed delivery",
+			opts:     []Option{WithGuaranteedDelivery(time.Second)},
+			sig:      SignalExitStatus(143),
+			wantErr:  false,
+			wantBody: "signal sent after the caller's context ended: context canceled",
+		},
+		{
+			name:    "start with guaranteed delivery",
+			opts:    []Option{WithGuaranteedDelivery(time.Second)},
+			sig:     SignalStart,
+			wantErr: true,
+		},
+		{
+			name:    "fail without guaranteed delivery",
+			opts:    []Option{},
+			sig:     SignalFail,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotBody = ""
+			opts, err := optsFromDefaults(append(tt.opts, WithURL(server.URL)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			if err := request(ctx, opts, &healthchecksBackend{root: opts.RootURL, path: "6da9bc25-880d-4a73-a0e5-e833405e206f"}, tt.sig, sendOptions{}); (err != nil) != tt.wantErr {
+				t.Errorf("request() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(gotBody, tt.wantBody) {
+				t.Errorf("request() body = %q, want %q", gotBody, tt.wantBody)
+			}
+		})
+	}
+}
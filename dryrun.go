@@ -0,0 +1,30 @@
+package healthchecks
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+const redacted = "****"
+
+// redactPath replaces the secret part of a check's path, i.e. the UUID or ping key, with asterisks.
+func redactPath(path string) string {
+	secret, rest, _ := strings.Cut(strings.TrimLeft(path, "/"), "/")
+	if secret == "" {
+		return path
+	}
+	if rest == "" {
+		return redacted
+	}
+	return redacted + "/" + rest
+}
+
+// dryRun writes the request which would have been sent for sig to w.
+func dryRun(w io.Writer, sig Signal, method string, u *url.URL, body []byte) error {
+	if _, err := fmt.Fprintf(w, "healthchecks dry run: signal=%s method=%s url=%s body=%q\n", sig, method, u, body); err != nil {
+		return fmt.Errorf("writing dry run: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package healthchecks
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestRedactPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "uuid", path: "/6da9bc25-880d-4a73-a0e5-e833405e206f", want: "****"},
+		{name: "slug", path: "rk9bbOJREu6nOWeHGjlnDQ/sample-check", want: "****/sample-check"},
+		{name: "multiple slashes", path: "//rk9bbOJREu6nOWeHGjlnDQ//sample-check", want: "****//sample-check"},
+		{name: "empty", path: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactPath(tt.path); got != tt.want {
+				t.Errorf("redactPath(%s) = %s, want %s", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		call func(p *Project) error
+		want string
+	}{
+		{
+			name: "success",
+			opts: []Option{},
+			call: func(p *Project) error {
+				return p.Success(context.Background(), "sample-check")
+			},
+			want: "healthchecks dry run: signal=success method=GET url=https://hc-ping.com/****/sample-check body=\"\"\n",
+		},
+		{
+			name: "log with create",
+			opts: []Option{WithAutoCreate()},
+			call: func(p *Project) error {
+				return p.Log(context.Background(), "sample-check", "foo\nbar")
+			},
+			want: "healthchecks dry run: signal=log method=POST url=https://hc-ping.com/****/sample-check/log?create=1 body=\"foo\\nbar\"\n",
+		},
+		{
+			name: "exit status via slug",
+			opts: []Option{WithURL("https://example.com/ping")},
+			call: func(p *Project) error {
+				return p.Slug("sample-check").ExitStatus(context.Background(), 3)
+			},
+			want: "healthchecks dry run: signal=exit-status:3 method=GET url=https://example.com/ping/****/sample-check/3 body=\"\"\n",
+		},
+		{
+			name: "disabled",
+			opts: []Option{WithDisabled()},
+			call: func(p *Project) error {
+				return p.Fail(context.Background(), "sample-check")
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			p, err := NewProject("rk9bbOJREu6nOWeHGjlnDQ", append(tt.opts, WithDryRun(buf))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.call(p); err != nil {
+				t.Errorf("dry run error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("dry run output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDryRunFromEnv(t *testing.T) {
+	t.Setenv("HC_DRY_RUN", "true")
+	t.Setenv("HC_DISABLED", "")
+
+	p, err := NewProject("rk9bbOJREu6nOWeHGjlnDQ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.opts.DryRun == nil {
+		t.Error("NewProject() with HC_DRY_RUN=true did not enable dry run")
+	}
+
+	t.Setenv("HC_DRY_RUN", "maybe")
+	if _, err := NewProject("rk9bbOJREu6nOWeHGjlnDQ"); err == nil {
+		t.Error("NewProject() with HC_DRY_RUN=maybe error = nil, want error")
+	}
+}
+
+func TestEnvOnlyForNotifiers(t *testing.T) {
+	t.Setenv("HC_DISABLED", "maybe")
+	t.Setenv("HC_DRY_RUN", "")
+
+	if _, err := NewAPIClient("apikey"); err != nil {
+		t.Errorf("NewAPIClient() with HC_DISABLED=maybe error = %v, want nil", err)
+	}
+	if _, err := NewUUID("6da9bc25-880d-4a73-a0e5-e833405e206f"); err == nil {
+		t.Error("NewUUID() with HC_DISABLED=maybe error = nil, want error")
+	}
+}
@@ -0,0 +1,105 @@
+package healthchecks
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var dsnSchemes = map[string]string{
+	"hc":       "https",
+	"hc+https": "https",
+	"hc+http":  "http",
+}
+
+// FromDSN constructs a new Notifier from a single connection string (DSN).
+//
+// The DSN should be in one of the following formats:
+//
+//	hc://<ping-key>@<host>[/<prefix>]/<slug>[?<params>]
+//	hc://<host>[/<prefix>]/<uuid>[?<params>]
+//
+// The scheme hc (or hc+https) uses HTTPS, hc+http uses plain HTTP.
+// Supported parameters are create=1 (see [WithAutoCreate]), rid=<uuid> (see [WithRunID])
+// and timeout=<duration> (see [WithTimeout]), e.g. hc://pingkey@hc-ping.com/slug?create=1&timeout=5s.
+//
+// Options parsed from the DSN are applied before opts.
+// Note that any option which overrides the URL will be ignored. With [WithURLs], the URL is the primary endpoint.
+func FromDSN(dsn string, opts ...Option) (Notifier, error) {
+	parsed, err := parseURL(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parsing DSN: %w", err)
+	}
+
+	scheme, ok := dsnSchemes[parsed.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("invalid DSN scheme '%s', expected one of hc, hc+https or hc+http", parsed.Scheme)
+	}
+	if parsed.Host == "" {
+		return nil, errors.New("invalid DSN: missing host")
+	}
+
+	dsnOpts, err := dsnOptions(parsed.Query())
+	if err != nil {
+		return nil, err
+	}
+	options, err := notifierOpts(append(dsnOpts, opts...))
+	if err != nil {
+		return nil, err
+	}
+
+	segments := pathSegments(parsed.Path)
+	id := segments[len(segments)-1]
+	if id == "" {
+		return nil, errors.New("invalid DSN: missing UUID or slug")
+	}
+
+	// override URL
+	options.setRootURL(rootURL(scheme, parsed.Host, segments[:len(segments)-1]))
+
+	if parsed.User == nil {
+		check, err := newUUID(id, options)
+		if err != nil {
+			return nil, err
+		}
+		return check, nil
+	}
+	project, err := newProject(parsed.User.Username(), options)
+	if err != nil {
+		return nil, err
+	}
+	if err := project.validateSlug(id); err != nil {
+		return nil, err
+	}
+	return project.Slug(id), nil
+}
+
+func dsnOptions(query url.Values) ([]Option, error) {
+	var opts []Option
+	if query.Has("timeout") {
+		value := query.Get("timeout")
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DSN parameter timeout=%s: %w", value, err)
+		}
+		opts = append(opts, WithTimeout(timeout))
+		query.Del("timeout")
+	}
+
+	urlOpts, err := urlOptions(query)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, urlOpts...), nil
+}
+
+func isDSN(u string) bool {
+	scheme, _, ok := strings.Cut(u, "://")
+	if !ok {
+		return false
+	}
+	_, ok = dsnSchemes[scheme]
+	return ok
+}
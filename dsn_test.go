@@ -0,0 +1,99 @@
+package healthchecks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFromDSN(t *testing.T) {
+	tests := []struct {
+		name    string
+		dsn     string
+		want    Notifier
+		wantErr bool
+	}{
+		{
+			name: "uuid",
+			dsn:  "hc://hc-ping.com/6da9bc25-880d-4a73-a0e5-e833405e206f",
+			want: &Check{
+				path: newSecret("/6da9bc25-880d-4a73-a0e5-e833405e206f"),
+				opts: &options{
+					RootURL:    mustURL("https://hc-ping.com"),
+					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "slug with prefix",
+			dsn:  "hc+http://rk9bbOJREu6nOWeHGjlnDQ@example.com:8000/ping/sample-check",
+			want: &Check{
+				path: newSecret("rk9bbOJREu6nOWeHGjlnDQ/sample-check"),
+				opts: &options{
+					RootURL:    mustURL("http://example.com:8000/ping"),
+					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "with parameters",
+			dsn:  "hc://rk9bbOJREu6nOWeHGjlnDQ@hc-ping.com/sample-check?create=1&timeout=5s",
+			want: &Check{
+				path: newSecret("rk9bbOJREu6nOWeHGjlnDQ/sample-check"),
+				opts: &options{
+					RootURL:    mustURL("https://hc-ping.com"),
+					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    5 * time.Second,
+					Create:     true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid scheme",
+			dsn:     "https://hc-ping.com/6da9bc25-880d-4a73-a0e5-e833405e206f",
+			wantErr: true,
+		},
+		{
+			name:    "missing host",
+			dsn:     "hc:///6da9bc25-880d-4a73-a0e5-e833405e206f",
+			wantErr: true,
+		},
+		{
+			name:    "missing uuid",
+			dsn:     "hc://hc-ping.com/",
+			wantErr: true,
+		},
+		{
+			name:    "invalid slug",
+			dsn:     "hc://rk9bbOJREu6nOWeHGjlnDQ@hc-ping.com/Sample Check",
+			wantErr: true,
+		},
+		{
+			name:    "invalid timeout",
+			dsn:     "hc://hc-ping.com/6da9bc25-880d-4a73-a0e5-e833405e206f?timeout=5",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported parameter",
+			dsn:     "hc://hc-ping.com/6da9bc25-880d-4a73-a0e5-e833405e206f?foo=bar",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromDSN(tt.dsn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FromDSN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromDSN() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package healthchecks
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const defaultEnvPrefix = "HC"
+
+// envPrefixOption sets the prefix of the environment variables read by notifier constructors,
+// see [notifierOpts]. It is only used by [FromEnv].
+type envPrefixOption string
+
+var _ Option = envPrefixOption("")
+
+func (envPrefixOption) apply(*options) error {
+	return nil
+}
+
+// notifierOpts applies the options enabled globally using environment variables and then opts to the defaults.
+//
+// The environment variables are named <PREFIX>_DISABLED and <PREFIX>_DRY_RUN, where the prefix is HC
+// unless overridden by [envPrefixOption]. See [WithDisabled] and [WithDryRun].
+func notifierOpts(opts []Option) (*options, error) {
+	prefix := defaultEnvPrefix
+	for _, o := range opts {
+		if p, ok := o.(envPrefixOption); ok {
+			prefix = string(p)
+		}
+	}
+	envOpts, err := optsFromEnv(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return optsFromDefaults(append(envOpts, opts...))
+}
+
+// optsFromEnv returns the options which are enabled globally using the environment variables
+// <prefix>_DISABLED and <prefix>_DRY_RUN.
+func optsFromEnv(prefix string) ([]Option, error) {
+	var opts []Option
+	disabled, err := envBool(prefix + "_DISABLED")
+	if err != nil {
+		return nil, err
+	}
+	if disabled {
+		opts = append(opts, WithDisabled())
+	}
+	dryRun, err := envBool(prefix + "_DRY_RUN")
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		opts = append(opts, WithDryRun(os.Stderr))
+	}
+	return opts, nil
+}
+
+// envBool parses the environment variable name as accepted by [strconv.ParseBool], false if it is empty.
+func envBool(name string) (bool, error) {
+	s := os.Getenv(name)
+	if s == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("parsing %s: %w", name, err)
+	}
+	return b, nil
+}
+
+// FromEnv constructs a new Notifier from environment variables.
+//
+// The variable names are composed of prefix, an underscore and one of the following names,
+// e.g. HC_UUID for the prefix "HC" (which is also used if prefix is empty):
+//
+//   - PING_URL: root URL of the ping endpoint (see [WithURL]), a full check URL (see [FromURL]) or a DSN (see [FromDSN])
+//   - UUID: UUID of the check (see [NewUUID])
+//   - PING_KEY: ping key of the project (see [NewProject])
+//   - SLUG: slug of the check, required if PING_KEY is set (see [Project.Slug])
+//   - TIMEOUT: request timeout as accepted by [time.ParseDuration] (see [WithTimeout])
+//   - DISABLED: if set to a true value as accepted by [strconv.ParseBool], no requests will be sent (see [WithDisabled])
+//   - DRY_RUN: if set to a true value, requests are written to [os.Stderr] instead of being sent (see [WithDryRun])
+//
+// Either UUID, PING_KEY or a full check URL in PING_URL is required, unless DISABLED is set.
+// With a prefix other than HC, HC_DISABLED and HC_DRY_RUN are ignored.
+//
+// Options from the environment are applied before opts.
+// With [WithURLs], the URL in PING_URL is the primary endpoint.
+func FromEnv(prefix string, opts ...Option) (Notifier, error) {
+	if prefix == "" {
+		prefix = defaultEnvPrefix
+	}
+	name := func(key string) string {
+		return prefix + "_" + key
+	}
+
+	var envOpts []Option
+	if s := os.Getenv(name("TIMEOUT")); s != "" {
+		timeout, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", name("TIMEOUT"), err)
+		}
+		envOpts = append(envOpts, WithTimeout(timeout))
+	}
+	// DISABLED and DRY_RUN are applied by the constructors, see notifierOpts
+	envOpts = append(envOpts, envPrefixOption(prefix))
+	disabled, err := envBool(name("DISABLED"))
+	if err != nil {
+		return nil, err
+	}
+
+	pingURL := os.Getenv(name("PING_URL"))
+	uuid := os.Getenv(name("UUID"))
+	pingKey := os.Getenv(name("PING_KEY"))
+	slug := os.Getenv(name("SLUG"))
+
+	if pingURL != "" && (uuid != "" || pingKey != "") {
+		envOpts = append(envOpts, WithURL(pingURL))
+	}
+	allOpts := append(envOpts, opts...)
+
+	switch {
+	case uuid != "":
+		check, err := NewUUID(uuid, allOpts...)
+		if err != nil {
+			return nil, err
+		}
+		if err := primaryEnvURL(check.opts, pingURL); err != nil {
+			return nil, err
+		}
+		return check, nil
+	case pingKey != "":
+		if slug == "" {
+			return nil, fmt.Errorf("%s is required if %s is set", name("SLUG"), name("PING_KEY"))
+		}
+		project, err := NewProject(pingKey, allOpts...)
+		if err != nil {
+			return nil, err
+		}
+		if err := project.validateSlug(slug); err != nil {
+			return nil, err
+		}
+		if err := primaryEnvURL(project.opts, pingURL); err != nil {
+			return nil, err
+		}
+		return project.Slug(slug), nil
+	case pingURL != "":
+		return FromURL(pingURL, allOpts...)
+	case disabled:
+		options, err := notifierOpts(allOpts)
+		if err != nil {
+			return nil, err
+		}
+		return &Check{opts: options}, nil
+	default:
+		return nil, fmt.Errorf("one of %s, %s or %s is required", name("UUID"), name("PING_KEY"), name("PING_URL"))
+	}
+}
+
+// primaryEnvURL makes pingURL the primary endpoint, if failover endpoints are set via [WithURLs].
+func primaryEnvURL(opts *options, pingURL string) error {
+	if pingURL == "" || opts.Endpoints == nil {
+		return nil
+	}
+	root, err := parseRootURL(pingURL)
+	if err != nil {
+		return err
+	}
+	opts.setRootURL(root)
+	return nil
+}
@@ -0,0 +1,151 @@
+package healthchecks
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		env     map[string]string
+		want    Notifier
+		wantErr bool
+	}{
+		{
+			name:   "uuid",
+			prefix: "",
+			env: map[string]string{
+				"HC_UUID": "6da9bc25-880d-4a73-a0e5-e833405e206f",
+			},
+			want: &Check{
+				path: newSecret("/6da9bc25-880d-4a73-a0e5-e833405e206f"),
+				opts: defaultOptions(),
+			},
+			wantErr: false,
+		},
+		{
+			name:   "ping key and slug with URL",
+			prefix: "BACKUP",
+			env: map[string]string{
+				"BACKUP_PING_URL": "https://example.com/ping",
+				"BACKUP_PING_KEY": "rk9bbOJREu6nOWeHGjlnDQ",
+				"BACKUP_SLUG":     "sample-check",
+			},
+			want: &Check{
+				path: newSecret("rk9bbOJREu6nOWeHGjlnDQ/sample-check"),
+				opts: &options{
+					RootURL:    mustURL("https://example.com/ping"),
+					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "DSN",
+			prefix: "HC",
+			env: map[string]string{
+				"HC_PING_URL": "hc://hc-ping.com/6da9bc25-880d-4a73-a0e5-e833405e206f",
+			},
+			want: &Check{
+				path: newSecret("/6da9bc25-880d-4a73-a0e5-e833405e206f"),
+				opts: &options{
+					RootURL:    mustURL("https://hc-ping.com"),
+					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "disabled without check",
+			prefix: "HC",
+			env: map[string]string{
+				"HC_DISABLED": "true",
+			},
+			want: &Check{
+				opts: &options{
+					RootURL:    defaultOptions().RootURL,
+					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
+					Disabled:   true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "dry run with prefix",
+			prefix: "BACKUP",
+			env: map[string]string{
+				"BACKUP_UUID":    "6da9bc25-880d-4a73-a0e5-e833405e206f",
+				"BACKUP_DRY_RUN": "true",
+				"HC_DISABLED":    "maybe", // ignored with another prefix
+			},
+			want: &Check{
+				path: newSecret("/6da9bc25-880d-4a73-a0e5-e833405e206f"),
+				opts: &options{
+					RootURL:    defaultOptions().RootURL,
+					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
+					DryRun:     os.Stderr,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			prefix:  "HC",
+			env:     map[string]string{},
+			wantErr: true,
+		},
+		{
+			name:   "ping key without slug",
+			prefix: "HC",
+			env: map[string]string{
+				"HC_PING_KEY": "rk9bbOJREu6nOWeHGjlnDQ",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid timeout",
+			prefix: "HC",
+			env: map[string]string{
+				"HC_UUID":    "6da9bc25-880d-4a73-a0e5-e833405e206f",
+				"HC_TIMEOUT": "ten seconds",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid disabled",
+			prefix: "HC",
+			env: map[string]string{
+				"HC_UUID":     "6da9bc25-880d-4a73-a0e5-e833405e206f",
+				"HC_DISABLED": "maybe",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"PING_URL", "UUID", "PING_KEY", "SLUG", "TIMEOUT", "DISABLED", "DRY_RUN"} {
+				t.Setenv("HC_"+key, "")
+				t.Setenv("BACKUP_"+key, "")
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			got, err := FromEnv(tt.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FromEnv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromEnv() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
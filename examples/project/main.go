@@ -8,7 +8,7 @@ import (
 )
 
 const (
-	_pingKey = "mysecretpingkey"
+	_pingKey = "mysecretpingkey1234567"
 	_slug    = "foo"
 )
 
@@ -0,0 +1,188 @@
+// Package exporter exposes the status of healthchecks.io checks as Prometheus metrics.
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/stnokott/healthchecks"
+)
+
+// states are all states exposed by the status metric.
+var states = []healthchecks.CheckState{
+	healthchecks.StateNew,
+	healthchecks.StateStarted,
+	healthchecks.StateUp,
+	healthchecks.StateGrace,
+	healthchecks.StateDown,
+	healthchecks.StatePaused,
+}
+
+// checkLabelNames are the labels of all metrics describing a check.
+var checkLabelNames = []string{"id", "name", "slug", "tags"}
+
+var (
+	statusDesc = prometheus.NewDesc("healthchecks_check_status",
+		"Status of the check, 1 for the current status.", append(checkLabelNames, "status"), nil)
+	lastPingAgeDesc = prometheus.NewDesc("healthchecks_check_last_ping_age_seconds",
+		"Seconds since the check received its last ping.", checkLabelNames, nil)
+	pingsDesc = prometheus.NewDesc("healthchecks_check_pings_total",
+		"Number of pings the check received.", checkLabelNames, nil)
+	timeoutDesc = prometheus.NewDesc("healthchecks_check_timeout_seconds",
+		"Expected period between pings of the check.", checkLabelNames, nil)
+	graceDesc = prometheus.NewDesc("healthchecks_check_grace_seconds",
+		"Grace time of the check.", checkLabelNames, nil)
+	upDesc = prometheus.NewDesc("healthchecks_exporter_up",
+		"Whether the last poll of the management API succeeded.", nil, nil)
+	lastPollDesc = prometheus.NewDesc("healthchecks_exporter_last_poll_timestamp_seconds",
+		"Time of the last successful poll of the management API.", nil, nil)
+)
+
+// Collector periodically polls the checks of a project via the management API and exposes them as metrics:
+//
+//	healthchecks_check_status{id,name,slug,tags,status}          1 for the current status of the check, 0 for all others
+//	healthchecks_check_last_ping_age_seconds{id,name,slug,tags}  seconds since the last ping, absent if there was none
+//	healthchecks_check_pings_total{id,name,slug,tags}            number of pings received
+//	healthchecks_check_timeout_seconds{id,name,slug,tags}        expected period, absent for checks with a cron schedule
+//	healthchecks_check_grace_seconds{id,name,slug,tags}          grace time
+//	healthchecks_exporter_up                                     1 if the last poll succeeded
+//	healthchecks_exporter_last_poll_timestamp_seconds            time of the last successful poll
+//
+// The id label is the unique key of the check (see [healthchecks.CheckInfo.UniqueKey]), since names and slugs
+// may be ambiguous and UUIDs are secrets.
+//
+// It implements [prometheus.Collector], so it can be registered with an existing registry.
+// [Collector.Handler] serves it standalone.
+//
+// Use [NewCollector] for obtaining a new instance.
+type Collector struct {
+	client *healthchecks.APIClient
+	opts   *options
+
+	mu     sync.RWMutex
+	checks []healthchecks.CheckInfo
+	polled time.Time // time of the last successful poll
+	err    error     // error of the last poll
+}
+
+// compile-time interface implementation check
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector creates a new instance of [Collector], polling via client.
+//
+// Call [Collector.Run] to start polling.
+func NewCollector(client *healthchecks.APIClient, opts ...Option) (*Collector, error) {
+	if client == nil {
+		return nil, errors.New("API client must not be nil")
+	}
+	options := defaultOptions()
+	for _, o := range opts {
+		if err := o.apply(options); err != nil {
+			return nil, fmt.Errorf("applying option: %w", err)
+		}
+	}
+	return &Collector{
+		client: client,
+		opts:   options,
+		err:    errors.New("not polled yet"),
+	}, nil
+}
+
+// Run polls immediately and then in the configured interval until ctx is done.
+//
+// Failed polls are logged and exposed via healthchecks_exporter_up. The metrics of the last successful poll are kept.
+func (c *Collector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if err := c.Poll(ctx); err != nil && ctx.Err() == nil {
+			c.opts.Logger.Error("polling checks", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches the checks once.
+func (c *Collector) Poll(ctx context.Context) error {
+	checks, err := c.client.ListChecks(ctx, c.opts.Tags...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+	if err != nil {
+		return err
+	}
+	c.checks = checks
+	c.polled = time.Now()
+	return nil
+}
+
+// Handler returns an [http.Handler] serving the metrics of c, e.g. as scrape target of Prometheus.
+//
+// It uses a dedicated registry, so c doesn't need to be registered elsewhere.
+func (c *Collector) Handler() http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
+
+// Describe implements [prometheus.Collector].
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{statusDesc, lastPingAgeDesc, pingsDesc, timeoutDesc, graceDesc, upDesc, lastPollDesc} {
+		ch <- d
+	}
+}
+
+// Collect implements [prometheus.Collector].
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	checks, polled, pollErr := c.checks, c.polled, c.err
+	c.mu.RUnlock()
+	now := time.Now()
+
+	for i := range checks {
+		check := &checks[i]
+		labels := checkLabels(check)
+		for _, state := range states {
+			ch <- prometheus.MustNewConstMetric(statusDesc, prometheus.GaugeValue, boolValue(check.Status == state),
+				append(labels, string(state))...)
+		}
+		if !check.LastPing.IsZero() {
+			ch <- prometheus.MustNewConstMetric(lastPingAgeDesc, prometheus.GaugeValue, now.Sub(check.LastPing).Seconds(), labels...)
+		}
+		ch <- prometheus.MustNewConstMetric(pingsDesc, prometheus.CounterValue, float64(check.NPings), labels...)
+		if check.Timeout > 0 {
+			ch <- prometheus.MustNewConstMetric(timeoutDesc, prometheus.GaugeValue, check.Timeout.Seconds(), labels...)
+		}
+		ch <- prometheus.MustNewConstMetric(graceDesc, prometheus.GaugeValue, check.Grace.Seconds(), labels...)
+	}
+
+	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, boolValue(pollErr == nil))
+	if !polled.IsZero() {
+		ch <- prometheus.MustNewConstMetric(lastPollDesc, prometheus.GaugeValue, float64(polled.UnixMilli())/1000)
+	}
+}
+
+// checkLabels returns the values of checkLabelNames for check.
+func checkLabels(check *healthchecks.CheckInfo) []string {
+	return []string{check.UniqueKey, check.Name, check.Slug, strings.Join(check.Tags, " ")}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,241 @@
+package exporter
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stnokott/healthchecks"
+)
+
+const testChecksResponse = `{"checks": [
+	{"name": "Cron \"nightly\"", "slug": "cron", "tags": "", "grace": 60, "n_pings": 0, "status": "new",
+	 "last_ping": null, "unique_key": "a6c7b0a8a66bed0df66abfdab3c77736861703ee", "schedule": "0 3 * * *", "tz": "UTC"},
+	{"name": "Backup", "slug": "backup", "tags": "prod db", "grace": 3600, "n_pings": 42, "status": "down",
+	 "last_ping": "2024-01-02T03:04:05+00:00", "uuid": "6da9bc25-880d-4a73-a0e5-e833405e206f", "timeout": 86400}
+]}`
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+const (
+	// backupID is the unique key derived from the UUID of Backup.
+	backupID = "82fe8f4a6c34a8f02e4d0afed71360b33138dff8"
+	cronID   = "a6c7b0a8a66bed0df66abfdab3c77736861703ee"
+)
+
+// labels formats the labels of a check in the order of the text exposition format.
+func labels(id, name, slug, status, tags string) string {
+	s := `{id="` + id + `",name="` + name + `",slug="` + slug + `"`
+	if status != "" {
+		s += `,status="` + status + `"`
+	}
+	return s + `,tags="` + tags + `"}`
+}
+
+var (
+	backupLabels = labels(backupID, "Backup", "backup", "", "prod db")
+	cronLabels   = labels(cronID, `Cron \"nightly\"`, "cron", "", "")
+)
+
+// newTestCollector creates a collector polling a test server responding with checks, which fails while fail is set.
+func newTestCollector(t *testing.T, fail *atomic.Bool, checks string, opts ...Option) *Collector {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+			return
+		}
+		_, _ = io.WriteString(w, checks)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := healthchecks.NewAPIClient("apikey", healthchecks.WithAPIURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v", err)
+	}
+	c, err := NewCollector(client, append([]Option{WithLogger(testLogger)}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewCollector() error = %v", err)
+	}
+	return c
+}
+
+func scrape(t *testing.T, c *Collector) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, body: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %s", ct)
+	}
+	return rec.Body.String()
+}
+
+func TestCollectorMetrics(t *testing.T) {
+	var fail atomic.Bool
+	c := newTestCollector(t, &fail, testChecksResponse)
+	if err := c.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	body := scrape(t, c)
+
+	wantLines := []string{
+		"# TYPE healthchecks_check_status gauge",
+		`healthchecks_check_status` + labels(backupID, "Backup", "backup", "down", "prod db") + ` 1`,
+		`healthchecks_check_status` + labels(backupID, "Backup", "backup", "up", "prod db") + ` 0`,
+		`healthchecks_check_status` + labels(cronID, `Cron \"nightly\"`, "cron", "new", "") + ` 1`,
+		"# TYPE healthchecks_check_pings_total counter",
+		`healthchecks_check_pings_total` + backupLabels + ` 42`,
+		`healthchecks_check_pings_total` + cronLabels + ` 0`,
+		`healthchecks_check_timeout_seconds` + backupLabels + ` 86400`,
+		`healthchecks_check_grace_seconds` + backupLabels + ` 3600`,
+		`healthchecks_check_grace_seconds` + cronLabels + ` 60`,
+		"healthchecks_exporter_up 1",
+	}
+	for _, want := range wantLines {
+		if !strings.Contains(body, want+"\n") {
+			t.Errorf("metrics missing %q", want)
+		}
+	}
+
+	// no timeout for cron checks, no age without pings
+	for _, unwanted := range []string{
+		`healthchecks_check_timeout_seconds` + cronLabels,
+		`healthchecks_check_last_ping_age_seconds` + cronLabels,
+	} {
+		if strings.Contains(body, unwanted) {
+			t.Errorf("metrics contain %q", unwanted)
+		}
+	}
+	if !strings.Contains(body, `healthchecks_check_last_ping_age_seconds`+backupLabels+` `) {
+		t.Error("metrics missing last ping age of Backup")
+	}
+	if strings.Contains(body, "6da9bc25-880d-4a73-a0e5-e833405e206f") {
+		t.Error("metrics contain the UUID of Backup")
+	}
+}
+
+func TestCollectorRegister(t *testing.T) {
+	// names and slugs aren't unique
+	const checks = `{"checks": [
+		{"name": "Backup", "slug": "backup", "status": "up", "uuid": "6da9bc25-880d-4a73-a0e5-e833405e206f"},
+		{"name": "Backup", "slug": "backup", "status": "down", "uuid": "0f8e1a4c-2b7d-4e59-9c36-5d1a7b8e9f20"}
+	]}`
+	var fail atomic.Bool
+	c := newTestCollector(t, &fail, checks)
+	if err := c.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() == "healthchecks_check_pings_total" && len(f.GetMetric()) != 2 {
+			t.Errorf("healthchecks_check_pings_total has %d samples, want 2", len(f.GetMetric()))
+		}
+	}
+}
+
+func TestCollectorPollFailure(t *testing.T) {
+	var fail atomic.Bool
+	c := newTestCollector(t, &fail, testChecksResponse)
+
+	if body := scrape(t, c); !strings.Contains(body, "healthchecks_exporter_up 0\n") {
+		t.Error("exporter up before first poll")
+	}
+	if err := c.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	// failed polls keep the previous metrics
+	fail.Store(true)
+	if err := c.Poll(context.Background()); err == nil {
+		t.Fatal("Poll() error = nil, want error")
+	}
+	body := scrape(t, c)
+	if !strings.Contains(body, "healthchecks_exporter_up 0\n") {
+		t.Error("exporter up after failed poll")
+	}
+	if !strings.Contains(body, `healthchecks_check_pings_total`+backupLabels+` 42`) {
+		t.Error("metrics of previous poll dropped")
+	}
+	if !strings.Contains(body, "healthchecks_exporter_last_poll_timestamp_seconds ") {
+		t.Error("metrics missing last poll timestamp")
+	}
+}
+
+func TestCollectorRun(t *testing.T) {
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		if got := r.URL.Query()["tag"]; len(got) != 1 || got[0] != "prod" {
+			t.Errorf("tags = %v, want [prod]", got)
+		}
+		_, _ = io.WriteString(w, `{"checks": []}`)
+	}))
+	defer srv.Close()
+
+	client, err := healthchecks.NewAPIClient("apikey", healthchecks.WithAPIURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v", err)
+	}
+	c, err := NewCollector(client, WithInterval(10*time.Millisecond), WithTags("prod"), WithLogger(testLogger))
+	if err != nil {
+		t.Fatalf("NewCollector() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Run(ctx)
+		close(done)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for polls.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+	if n := polls.Load(); n < 3 {
+		t.Errorf("polled %d times, want >= 3", n)
+	}
+}
+
+func TestNewCollectorInvalid(t *testing.T) {
+	client, err := healthchecks.NewAPIClient("apikey")
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v", err)
+	}
+	tests := []struct {
+		name   string
+		client *healthchecks.APIClient
+		opt    Option
+	}{
+		{name: "nil client", client: nil, opt: WithTags()},
+		{name: "zero interval", client: client, opt: WithInterval(0)},
+		{name: "nil logger", client: client, opt: WithLogger(nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCollector(tt.client, tt.opt); err == nil {
+				t.Error("NewCollector() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// defaultInterval is the default interval for polling the management API.
+const defaultInterval = time.Minute
+
+type options struct {
+	Interval time.Duration
+	Tags     []string
+	Logger   *slog.Logger
+}
+
+func defaultOptions() *options {
+	return &options{
+		Interval: defaultInterval,
+		Logger:   slog.Default(),
+	}
+}
+
+// Option applies a configuration option to a [Collector].
+type Option interface {
+	apply(opts *options) error
+}
+
+type intervalOption time.Duration
+
+var _ Option = intervalOption(0)
+
+func (i intervalOption) apply(opts *options) error {
+	if i <= 0 {
+		return fmt.Errorf("interval is %d, needs to be > 0", i)
+	}
+	opts.Interval = time.Duration(i)
+	return nil
+}
+
+// WithInterval sets the interval for polling the management API.
+//
+// Defaults to one minute. Keep the rate limits of the management API in mind.
+func WithInterval(i time.Duration) Option {
+	return intervalOption(i)
+}
+
+type tagsOption []string
+
+var _ Option = tagsOption(nil)
+
+func (t tagsOption) apply(opts *options) error {
+	opts.Tags = append(opts.Tags, t...)
+	return nil
+}
+
+// WithTags only exposes checks having all of tags.
+func WithTags(tags ...string) Option {
+	return tagsOption(tags)
+}
+
+type loggerOption struct {
+	logger *slog.Logger
+}
+
+var _ Option = loggerOption{}
+
+func (l loggerOption) apply(opts *options) error {
+	if l.logger == nil {
+		return errors.New("logger must not be nil")
+	}
+	opts.Logger = l.logger
+	return nil
+}
+
+// WithLogger sets the logger for failed polls.
+//
+// Defaults to [slog.Default].
+func WithLogger(l *slog.Logger) Option {
+	return loggerOption{logger: l}
+}
@@ -0,0 +1,73 @@
+package healthchecks
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// endpoints tracks the health of the root URLs of replicas, see [WithURLs].
+//
+// It is safe for concurrent use.
+type endpoints struct {
+	urls []*url.URL // in order of preference
+
+	mu        sync.Mutex
+	preferred int    // index of the last endpoint which responded
+	failed    []bool // whether the last request to an endpoint failed
+}
+
+func newEndpoints(urls []*url.URL) *endpoints {
+	return &endpoints{
+		urls:   urls,
+		failed: make([]bool, len(urls)),
+	}
+}
+
+// withPrimary returns endpoints with u as the primary endpoint, followed by the other endpoints of e.
+func (e *endpoints) withPrimary(u *url.URL) *endpoints {
+	urls := []*url.URL{u}
+	for _, other := range e.urls {
+		if other.String() != u.String() {
+			urls = append(urls, other)
+		}
+	}
+	return newEndpoints(urls)
+}
+
+// order returns the indices of the endpoints in the order they should be tried:
+// the preferred endpoint first, followed by the healthy and then the failed ones, each in order of preference.
+func (e *endpoints) order() []int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	order := make([]int, 0, len(e.urls))
+	order = append(order, e.preferred)
+	for _, failed := range []bool{false, true} {
+		for i := range e.urls {
+			if i != e.preferred && e.failed[i] == failed {
+				order = append(order, i)
+			}
+		}
+	}
+	return order
+}
+
+// report records the result of a request to the endpoint with index i.
+func (e *endpoints) report(i int, ok bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failed[i] = !ok
+	if ok {
+		e.preferred = i
+	}
+}
+
+// shouldFailover reports whether the next endpoint should be tried after a ping resulting in e.
+func shouldFailover(e *PingEvent) bool {
+	return e.Outcome == OutcomeTransportError || e.StatusCode >= http.StatusInternalServerError
+}
+
+// reachedEndpoint reports whether a ping resulting in e was answered by the endpoint.
+func reachedEndpoint(e *PingEvent) bool {
+	return e.StatusCode != 0
+}
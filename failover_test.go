@@ -0,0 +1,238 @@
+package healthchecks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithURLsFailover(t *testing.T) {
+	type replica struct {
+		srv      *httptest.Server
+		status   atomic.Int32
+		requests atomic.Int32
+	}
+	newReplica := func() *replica {
+		r := &replica{}
+		r.status.Store(http.StatusOK)
+		r.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			r.requests.Add(1)
+			w.WriteHeader(int(r.status.Load()))
+			_, _ = w.Write([]byte("OK"))
+		}))
+		return r
+	}
+	primary, secondary := newReplica(), newReplica()
+	defer primary.srv.Close()
+	defer secondary.srv.Close()
+
+	project, err := NewProject(testRedactPingKey, WithURLs(primary.srv.URL, secondary.srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	steps := []struct {
+		name          string
+		primary       int32
+		secondary     int32
+		wantPrimary   int32
+		wantSecondary int32
+		wantErr       bool
+	}{
+		{name: "primary", primary: http.StatusOK, secondary: http.StatusOK, wantPrimary: 1},
+		{name: "primary fails", primary: http.StatusServiceUnavailable, secondary: http.StatusOK, wantPrimary: 1, wantSecondary: 1},
+		{name: "sticks to secondary", primary: http.StatusOK, secondary: http.StatusOK, wantSecondary: 1},
+		{name: "secondary fails", primary: http.StatusOK, secondary: http.StatusBadGateway, wantPrimary: 1, wantSecondary: 1},
+		{name: "sticks to primary", primary: http.StatusOK, secondary: http.StatusOK, wantPrimary: 1},
+		{name: "client error", primary: http.StatusBadRequest, secondary: http.StatusOK, wantPrimary: 1, wantErr: true},
+		{name: "all fail", primary: http.StatusInternalServerError, secondary: http.StatusInternalServerError, wantPrimary: 1, wantSecondary: 1, wantErr: true},
+	}
+	for _, step := range steps {
+		primary.status.Store(step.primary)
+		secondary.status.Store(step.secondary)
+		primary.requests.Store(0)
+		secondary.requests.Store(0)
+
+		if err := project.Slug("foo").Success(ctx); (err != nil) != step.wantErr {
+			t.Fatalf("%s: Success() error = %v, wantErr %v", step.name, err, step.wantErr)
+		}
+		if got := primary.requests.Load(); got != step.wantPrimary {
+			t.Errorf("%s: requests to primary = %d, want %d", step.name, got, step.wantPrimary)
+		}
+		if got := secondary.requests.Load(); got != step.wantSecondary {
+			t.Errorf("%s: requests to secondary = %d, want %d", step.name, got, step.wantSecondary)
+		}
+	}
+}
+
+func TestWithURLsTransportError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer srv.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close() // refuse connections
+
+	var events []PingEvent
+	check, err := NewUUID(testRedactUUID, WithURLs(down.URL, srv.URL), WithResultHook(func(e PingEvent) {
+		events = append(events, e)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := check.Success(context.Background()); err != nil {
+		t.Fatalf("Success() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("events = %d, want 1", len(events))
+	}
+	if e := events[0]; e.Attempt != 2 || e.Outcome != OutcomeSuccess || e.Check != srv.URL+"/"+redacted {
+		t.Errorf("event = %+v, want success at %s after 2 attempts", e, srv.URL)
+	}
+}
+
+func TestWithURLs(t *testing.T) {
+	tests := []struct {
+		name     string
+		urls     []string
+		wantRoot string
+		wantErr  bool
+	}{
+		{name: "single", urls: []string{"https://example.com"}, wantRoot: "https://example.com"},
+		{name: "multiple", urls: []string{"https://a.example.com/ping", "https://b.example.com/ping"}, wantRoot: "https://a.example.com/ping"},
+		{name: "empty", urls: nil, wantErr: true},
+		{name: "invalid fallback", urls: []string{"https://example.com", "example.com"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := defaultOptions()
+			err := WithURLs(tt.urls...).apply(opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := opts.RootURL.String(); got != tt.wantRoot {
+				t.Errorf("RootURL = %s, want %s", got, tt.wantRoot)
+			}
+			if (opts.Endpoints != nil) != (len(tt.urls) > 1) {
+				t.Errorf("Endpoints = %v, want failover for %d URLs", opts.Endpoints, len(tt.urls))
+			}
+		})
+	}
+
+	opts := defaultOptions()
+	for _, o := range []Option{WithURLs("https://a.example.com", "https://b.example.com"), WithURL("https://c.example.com")} {
+		if err := o.apply(opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if opts.Endpoints != nil {
+		t.Error("Endpoints not reset by WithURL")
+	}
+}
+
+func TestEndpointsOrder(t *testing.T) {
+	e := newEndpoints([]*url.URL{{Host: "a"}, {Host: "b"}, {Host: "c"}, {Host: "d"}})
+	if got, want := e.order(), []int{0, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order() = %v, want %v", got, want)
+	}
+	e.report(0, false)
+	e.report(1, false)
+	e.report(2, true)
+	if got, want := e.order(), []int{2, 3, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order() = %v, want %v", got, want)
+	}
+}
+
+func TestWithURLsCheckURL(t *testing.T) {
+	var requests []string
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests = append(requests, name)
+			_, _ = w.Write([]byte("OK"))
+		}))
+	}
+	a, b, own := newServer("a"), newServer("b"), newServer("own")
+	defer a.Close()
+	defer b.Close()
+	defer own.Close()
+	ownURL, err := url.Parse(own.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		new  func() (Notifier, error)
+	}{
+		{
+			name: "FromURL",
+			new: func() (Notifier, error) {
+				return FromURL(own.URL+"/"+testRedactUUID, WithURLs(a.URL, b.URL))
+			},
+		},
+		{
+			name: "FromURL with slug",
+			new: func() (Notifier, error) {
+				return FromURL(own.URL+"/"+testRedactPingKey+"/foo", WithURLs(a.URL, b.URL))
+			},
+		},
+		{
+			name: "FromDSN",
+			new: func() (Notifier, error) {
+				return FromDSN("hc+http://"+ownURL.Host+"/"+testRedactUUID, WithURLs(a.URL, b.URL))
+			},
+		},
+		{
+			name: "FromEnv",
+			new: func() (Notifier, error) {
+				t.Setenv("HC_PING_URL", own.URL)
+				t.Setenv("HC_UUID", testRedactUUID)
+				return FromEnv("HC", WithURLs(a.URL, b.URL))
+			},
+		},
+		{
+			name: "FromEnv with slug",
+			new: func() (Notifier, error) {
+				t.Setenv("HC_PING_URL", own.URL)
+				t.Setenv("HC_PING_KEY", testRedactPingKey)
+				t.Setenv("HC_SLUG", "foo")
+				return FromEnv("HC", WithURLs(a.URL, b.URL))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			n, err := tt.new()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := n.Success(context.Background()); err != nil {
+				t.Fatalf("Success() error = %v", err)
+			}
+			if want := []string{"own"}; !reflect.DeepEqual(requests, want) {
+				t.Errorf("requests = %v, want %v", requests, want)
+			}
+			check := n.(*Check)
+			if got := check.Redacted(); !strings.HasPrefix(got, own.URL+"/") {
+				t.Errorf("Redacted() = %s, want prefix %s", got, own.URL)
+			}
+			var fallbacks []string
+			for _, u := range check.opts.Endpoints.urls[1:] {
+				fallbacks = append(fallbacks, u.String())
+			}
+			if want := []string{a.URL, b.URL}; !reflect.DeepEqual(fallbacks, want) {
+				t.Errorf("fallback endpoints = %v, want %v", fallbacks, want)
+			}
+		})
+	}
+}
@@ -5,16 +5,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/url"
-	"strconv"
-	"strings"
 )
 
 // Project organizes multiple checks in a common project.
 //
 // Use [NewProject] for obtaining a new instance.
 type Project struct {
-	pingKey string
+	pingKey secret
 	opts    *options
 }
 
@@ -22,18 +19,29 @@ type Project struct {
 //
 // Sending signals via a project requires the project's "ping key" and the check's slug.
 // The ping key can be created under your project's settings.
+//
+// The ping key and all slugs are validated against the format used by healthchecks.io,
+// returning a [*ValidationError] if they don't match. Use [WithoutValidation] to disable this.
 func NewProject(pingKey string, opts ...Option) (*Project, error) {
+	options, err := notifierOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	return newProject(pingKey, options)
+}
+
+func newProject(pingKey string, options *options) (*Project, error) {
 	if pingKey == "" {
 		return nil, errors.New("project ping key must not be empty")
 	}
-
-	options, err := optsFromDefaults(opts)
-	if err != nil {
-		return nil, err
+	if !options.SkipValidation {
+		if err := validatePingKey(pingKey); err != nil {
+			return nil, err
+		}
 	}
 
 	return &Project{
-		pingKey: pingKey,
+		pingKey: newSecret(pingKey),
 		opts:    options,
 	}, nil
 }
@@ -54,121 +62,216 @@ type Notifier interface {
 	ExitStatus(ctx context.Context, code int) error
 }
 
-// TODO: exit status https://healthchecks.io/docs/http_api/#exitcode-uuid
+// Send sends sig to the project's check identified by slug.
+func (p *Project) Send(ctx context.Context, slug string, sig Signal, opts ...SendOption) error {
+	if err := p.validateSlug(slug); err != nil {
+		return err
+	}
+	call, err := sendOptsFrom(opts)
+	if err != nil {
+		return err
+	}
+	return request(ctx, p.opts, p.backend(slug), sig, call)
+}
+
+// backend returns the backend sending signals to the check identified by slug.
+func (p *Project) backend(slug string) *healthchecksBackend {
+	return &healthchecksBackend{root: p.opts.RootURL, path: p.pingKey.value() + "/" + slug}
+}
 
 // Start sends the "start" signal to the project's check identified by slug.
 func (p *Project) Start(ctx context.Context, slug string) error {
-	return request(ctx, p.opts, nil, p.pingKey, slug, "/start")
+	return p.Send(ctx, slug, SignalStart)
 }
 
 // Success sends the "success" signal to the project's check identified by slug.
 func (p *Project) Success(ctx context.Context, slug string) error {
-	return request(ctx, p.opts, nil, p.pingKey, slug)
+	return p.Send(ctx, slug, SignalSuccess)
 }
 
 // Fail sends the "fail" signal to the project's check identified by slug.
 func (p *Project) Fail(ctx context.Context, slug string) error {
-	return request(ctx, p.opts, nil, p.pingKey, slug, "/fail")
+	return p.Send(ctx, slug, SignalFail)
 }
 
 // Log sends the "log" signal with the attached message to the project's check identified by slug.
 func (p *Project) Log(ctx context.Context, slug string, msg string) error {
-	return request(ctx, p.opts, strings.NewReader(msg), p.pingKey, slug, "/log")
+	return p.Send(ctx, slug, SignalLog, WithBody(msg))
 }
 
 // ExitStatus sends the "exit-status" signal with the exit code to the project's check identified by slug.
 //
 // Success or failure of the check is determined by the exit code.
 func (p *Project) ExitStatus(ctx context.Context, slug string, code int) error {
-	return request(ctx, p.opts, nil, p.pingKey, slug, "/", strconv.Itoa(code))
+	return p.Send(ctx, slug, SignalExitStatus(code))
 }
 
 // Slug creates a new [Notifier] for a check in this [Project], indentified by its slug.
+//
+// If the slug is invalid, all signals sent via the returned [Notifier] fail with a [*ValidationError].
 func (p *Project) Slug(slug string) Notifier {
 	return &Check{
-		path: p.pingKey + "/" + slug,
+		path: newSecret(p.pingKey.value() + "/" + slug),
 		opts: p.opts,
+		err:  p.validateSlug(slug),
 	}
 }
 
+func (p *Project) validateSlug(slug string) error {
+	if p.opts.SkipValidation {
+		return nil
+	}
+	return validateSlug(slug)
+}
+
 // compile-time interface implementation check
 var _ Notifier = (*Check)(nil)
 
-// Check is an individual check, either obtained via [NewUUID] or [Project.Slug] (via [NewProject]).
+// Check is an individual check, either obtained via [NewUUID], [Project.Slug] (via [NewProject])
+// or [NewCheck] for other monitoring services.
 //
 // It implements [Notifier].
 type Check struct {
-	path string
-	opts *options
+	path    secret
+	backend Backend // nil for healthchecks.io checks, which are identified by path
+	opts    *options
+	err     error // returned by all signals if non-nil, e.g. when created with an invalid slug
 }
 
 // NewUUID creates a new instance of [Check], identified by its UUID.
 //
 // The UUID is in the format xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+// A [*ValidationError] is returned if it doesn't match, unless [WithoutValidation] is provided.
 func NewUUID(uuid string, opts ...Option) (*Check, error) {
+	options, err := notifierOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	return newUUID(uuid, options)
+}
+
+func newUUID(uuid string, options *options) (*Check, error) {
 	if uuid == "" {
 		return nil, errors.New("uuid must not be empty")
 	}
-	options, err := optsFromDefaults(opts)
-	if err != nil {
-		return nil, err
+	if !options.SkipValidation {
+		if err := validateUUID(uuid); err != nil {
+			return nil, err
+		}
 	}
 
 	return &Check{
-		path: "/" + uuid,
+		path: newSecret("/" + uuid),
 		opts: options,
 	}, nil
 }
 
-// FromURL constructs a new Notifier from the URL of a single (UUID-based) check.
+// FromURL constructs a new Notifier from the ping URL of a single check.
+//
+// The URL should be in one of the following formats:
+//
+//	http(s)://example.com[/prefix]/<uuid>[?<params>]
+//	http(s)://example.com[/prefix]/<ping-key>/<slug>[?<params>]
 //
-// The URL should be in the format http(s)://example.com/uuid.
+// Supported parameters are create=1 (see [WithAutoCreate]) and rid=<uuid> (see [WithRunID]).
+// URLs which already contain a signal suffix like /start or /fail are rejected.
+// DSNs as accepted by [FromDSN] are supported as well.
 //
-// Note that any option which overrides the URL will be ignored.
+// Note that any option which overrides the URL will be ignored. With [WithURLs], the URL is the primary endpoint.
 func FromURL(u string, opts ...Option) (Notifier, error) {
-	parsed, err := url.Parse(u)
+	if isDSN(u) {
+		return FromDSN(u, opts...)
+	}
+
+	parsed, err := parseURL(u)
 	if err != nil {
 		return nil, fmt.Errorf("parsing URL: %w", err)
 	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, errors.New("invalid URL: missing scheme or host")
+	}
 
-	// apply options
-	options, err := optsFromDefaults(opts)
+	urlOpts, err := urlOptions(parsed.Query())
+	if err != nil {
+		return nil, err
+	}
+	options, err := notifierOpts(append(urlOpts, opts...))
 	if err != nil {
 		return nil, err
 	}
 
-	// override URL
-	options.RootURL, _ = url.Parse(parsed.Scheme + "://" + parsed.Host) //nolint:errcheck
+	segments := pathSegments(parsed.Path)
+	last := len(segments) - 1
+	switch {
+	case segments[last] == "":
+		return nil, errors.New("invalid URL: missing UUID or slug")
+	case last >= 1 && isSignalSuffix(segments[last]) &&
+		(isUUID(segments[last-1]) || (last >= 2 && isPingKey(segments[last-2]))):
+		return nil, fmt.Errorf("invalid URL: already contains signal suffix '/%s'", segments[last])
+	case isUUID(segments[last]) || last == 0:
+		// override URL
+		options.setRootURL(rootURL(parsed.Scheme, parsed.Host, segments[:last]))
+		check, err := newUUID(segments[last], options)
+		if err != nil {
+			return nil, err
+		}
+		return check, nil
+	default:
+		// override URL
+		options.setRootURL(rootURL(parsed.Scheme, parsed.Host, segments[:last-1]))
+		project, err := newProject(segments[last-1], options)
+		if err != nil {
+			return nil, err
+		}
+		if err := project.validateSlug(segments[last]); err != nil {
+			return nil, err
+		}
+		return project.Slug(segments[last]), nil
+	}
+}
 
-	return &Check{
-		path: parsed.Path,
-		opts: options,
-	}, nil
+// Send sends sig to the check.
+func (c *Check) Send(ctx context.Context, sig Signal, opts ...SendOption) error {
+	if c.err != nil {
+		return c.err
+	}
+	call, err := sendOptsFrom(opts)
+	if err != nil {
+		return err
+	}
+	return request(ctx, c.opts, c.getBackend(), sig, call)
+}
+
+func (c *Check) getBackend() Backend {
+	if c.backend != nil {
+		return c.backend
+	}
+	return &healthchecksBackend{root: c.opts.RootURL, path: c.path.value()}
 }
 
 // Start sends the "start" signal to the check identified by its uuid.
 func (c *Check) Start(ctx context.Context) error {
-	return request(ctx, c.opts, nil, c.path, "/start")
+	return c.Send(ctx, SignalStart)
 }
 
 // Success sends the "success" signal to the check identified by its uuid.
 func (c *Check) Success(ctx context.Context) error {
-	return request(ctx, c.opts, nil, c.path)
+	return c.Send(ctx, SignalSuccess)
 }
 
 // Fail sends the "fail" signal to the check identified by its uuid.
 func (c *Check) Fail(ctx context.Context) error {
-	return request(ctx, c.opts, nil, c.path, "/fail")
+	return c.Send(ctx, SignalFail)
 }
 
 // Log sends the "log" signal with the attached message to the check identified by its uuid.
 func (c *Check) Log(ctx context.Context, msg string) error {
-	return request(ctx, c.opts, strings.NewReader(msg), c.path, "/log")
+	return c.Send(ctx, SignalLog, WithBody(msg))
 }
 
 // ExitStatus sends the "exit-status" signal with the exit code to the check identified by its uuid.
 //
 // Success or failure of the check is determined by the exit code.
 func (c *Check) ExitStatus(ctx context.Context, code int) error {
-	return request(ctx, c.opts, nil, c.path, "/", strconv.Itoa(code))
+	return c.Send(ctx, SignalExitStatus(code))
 }
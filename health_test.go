@@ -19,11 +19,11 @@ func TestNewProject(t *testing.T) {
 		{
 			name: "no options",
 			args: args{
-				pingKey: "ping key",
+				pingKey: "rk9bbOJREu6nOWeHGjlnDQ",
 				opts:    []Option{},
 			},
 			want: &Project{
-				pingKey: "ping key",
+				pingKey: newSecret("rk9bbOJREu6nOWeHGjlnDQ"),
 				opts:    defaultOptions(),
 			},
 			wantErr: false,
@@ -49,16 +49,45 @@ func TestNewProject(t *testing.T) {
 		{
 			name: "with option",
 			args: args{
-				pingKey: "foo bar",
+				pingKey: "rk9bbOJREu6nOWeHGjlnDQ",
 				opts: []Option{
 					WithURL("https://example.com"),
 				},
 			},
 			want: &Project{
-				pingKey: "foo bar",
+				pingKey: newSecret("rk9bbOJREu6nOWeHGjlnDQ"),
 				opts: &options{
 					RootURL:    mustURL("https://example.com"),
 					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid ping key",
+			args: args{
+				pingKey: "foo bar",
+				opts:    []Option{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid ping key without validation",
+			args: args{
+				pingKey: "foo bar",
+				opts: []Option{
+					WithoutValidation(),
+				},
+			},
+			want: &Project{
+				pingKey: newSecret("foo bar"),
+				opts: &options{
+					RootURL:        defaultOptions().RootURL,
+					HTTPClient:     defaultOptions().HTTPClient,
+					Timeout:        defaultTimeout,
+					SkipValidation: true,
 				},
 			},
 			wantErr: false,
@@ -91,13 +120,30 @@ func TestProjectSlug(t *testing.T) {
 		{
 			name: "valid",
 			p: &Project{
-				pingKey: "fooBar",
+				pingKey: newSecret("fooBar"),
+				opts:    defaultOptions(),
+			},
+			args: args{slug: "sluggy-slug"},
+			want: &Check{
+				path: newSecret("fooBar/sluggy-slug"),
+				opts: defaultOptions(),
+			},
+		},
+		{
+			name: "invalid",
+			p: &Project{
+				pingKey: newSecret("fooBar"),
 				opts:    defaultOptions(),
 			},
 			args: args{slug: "sluggySlug"},
 			want: &Check{
-				path: "fooBar/sluggySlug",
+				path: newSecret("fooBar/sluggySlug"),
 				opts: defaultOptions(),
+				err: &ValidationError{
+					Kind:   ErrInvalidSlug,
+					Value:  "sluggySlug",
+					Reason: "may only contain lowercase letters, digits, hyphens and underscores",
+				},
 			},
 		},
 	}
@@ -124,15 +170,41 @@ func TestNewUUID(t *testing.T) {
 		{
 			name: "valid",
 			args: args{
-				uuid: "abc-def",
+				uuid: "6da9bc25-880d-4a73-a0e5-e833405e206f",
 				opts: []Option{},
 			},
 			want: &Check{
-				path: "/abc-def",
+				path: newSecret("/6da9bc25-880d-4a73-a0e5-e833405e206f"),
 				opts: defaultOptions(),
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid",
+			args: args{
+				uuid: "abc-def",
+				opts: []Option{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid without validation",
+			args: args{
+				uuid: "abc-def",
+				opts: []Option{WithoutValidation()},
+			},
+			want: &Check{
+				path: newSecret("/abc-def"),
+				opts: &options{
+					RootURL:        defaultOptions().RootURL,
+					HTTPClient:     defaultOptions().HTTPClient,
+					Timeout:        defaultTimeout,
+					SkipValidation: true,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "empty uuid",
 			args: args{
@@ -171,14 +243,15 @@ func TestFromURL(t *testing.T) {
 		{
 			name: "simple URL",
 			args: args{
-				u:    "https://example.com/foo-bar-123",
+				u:    "https://example.com/6da9bc25-880d-4a73-a0e5-e833405e206f",
 				opts: []Option{},
 			},
 			want: &Check{
-				path: "/foo-bar-123",
+				path: newSecret("/6da9bc25-880d-4a73-a0e5-e833405e206f"),
 				opts: &options{
 					RootURL:    mustURL("https://example.com"),
 					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
 				},
 			},
 			wantErr: false,
@@ -186,18 +259,140 @@ func TestFromURL(t *testing.T) {
 		{
 			name: "subpaths",
 			args: args{
-				u:    "https://example.com/fuzz/foo-bar-123",
+				u:    "https://example.com/fuzz/6da9bc25-880d-4a73-a0e5-e833405e206f",
 				opts: []Option{},
 			},
 			want: &Check{
-				path: "/fuzz/foo-bar-123",
+				path: newSecret("/6da9bc25-880d-4a73-a0e5-e833405e206f"),
 				opts: &options{
-					RootURL:    mustURL("https://example.com"),
+					RootURL:    mustURL("https://example.com/fuzz"),
 					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "slug",
+			args: args{
+				u:    "https://hc-ping.com/rk9bbOJREu6nOWeHGjlnDQ/sample-check?create=1",
+				opts: []Option{},
+			},
+			want: &Check{
+				path: newSecret("rk9bbOJREu6nOWeHGjlnDQ/sample-check"),
+				opts: &options{
+					RootURL:    mustURL("https://hc-ping.com"),
+					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
+					Create:     true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "slug with subpaths",
+			args: args{
+				u:    "http://web:8000/ping/rk9bbOJREu6nOWeHGjlnDQ/sample-check",
+				opts: []Option{},
+			},
+			want: &Check{
+				path: newSecret("rk9bbOJREu6nOWeHGjlnDQ/sample-check"),
+				opts: &options{
+					RootURL:    mustURL("http://web:8000/ping"),
+					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "run ID",
+			args: args{
+				u:    "https://hc-ping.com/6da9bc25-880d-4a73-a0e5-e833405e206f?rid=0b1c1d2e-3f40-4152-8637-48495a6b7c8d",
+				opts: []Option{},
+			},
+			want: &Check{
+				path: newSecret("/6da9bc25-880d-4a73-a0e5-e833405e206f"),
+				opts: &options{
+					RootURL:    mustURL("https://hc-ping.com"),
+					HTTPClient: defaultOptions().HTTPClient,
+					Timeout:    defaultTimeout,
+					RunID:      "0b1c1d2e-3f40-4152-8637-48495a6b7c8d",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "custom path without validation",
+			args: args{
+				u:    "https://example.com/fuzz/foo-bar-123",
+				opts: []Option{WithoutValidation()},
+			},
+			want: &Check{
+				path: newSecret("fuzz/foo-bar-123"),
+				opts: &options{
+					RootURL:        mustURL("https://example.com"),
+					HTTPClient:     defaultOptions().HTTPClient,
+					Timeout:        defaultTimeout,
+					SkipValidation: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "uuid with signal suffix",
+			args: args{
+				u:    "https://hc-ping.com/6da9bc25-880d-4a73-a0e5-e833405e206f/fail",
+				opts: []Option{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "slug with exit status suffix",
+			args: args{
+				u:    "https://hc-ping.com/rk9bbOJREu6nOWeHGjlnDQ/sample-check/1",
+				opts: []Option{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid uuid",
+			args: args{
+				u:    "https://example.com/foo-bar-123",
+				opts: []Option{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid run ID",
+			args: args{
+				u:    "https://hc-ping.com/6da9bc25-880d-4a73-a0e5-e833405e206f?rid=1",
+				opts: []Option{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "unsupported parameter",
+			args: args{
+				u:    "https://hc-ping.com/6da9bc25-880d-4a73-a0e5-e833405e206f?foo=bar",
+				opts: []Option{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "missing host",
+			args: args{
+				u:    "/6da9bc25-880d-4a73-a0e5-e833405e206f",
+				opts: []Option{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,115 @@
+package healthchecks
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+)
+
+// Outcome classifies the result of a ping, see [PingEvent].
+type Outcome int
+
+const (
+	// OutcomeUnknown is the zero value, which isn't reported for any ping.
+	OutcomeUnknown Outcome = iota
+	// OutcomeSuccess means the ping was accepted.
+	OutcomeSuccess
+	// OutcomeSkipped means the ping wasn't sent, e.g. because of [WithDisabled], [WithDryRun] or [WithCoalescing],
+	// or because the backend doesn't support the signal.
+	OutcomeSkipped
+	// OutcomeNotFound means the check doesn't exist.
+	OutcomeNotFound
+	// OutcomeRateLimited means the ping was rejected because of too many pings.
+	OutcomeRateLimited
+	// OutcomeRejected means the ping was rejected for any other reason.
+	OutcomeRejected
+	// OutcomeTransportError means no response was received, e.g. because of a timeout or a refused connection.
+	OutcomeTransportError
+	// OutcomeError means the request couldn't be created, e.g. because the context was cancelled
+	// while waiting for [WithRateLimit].
+	OutcomeError
+	// OutcomeCircuitOpen means the ping wasn't sent because the [CircuitBreaker] is open.
+	OutcomeCircuitOpen
+)
+
+// String returns the name of o.
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeUnknown:
+		return "unknown"
+	case OutcomeSuccess:
+		return "success"
+	case OutcomeSkipped:
+		return "skipped"
+	case OutcomeNotFound:
+		return "not found"
+	case OutcomeRateLimited:
+		return "rate limited"
+	case OutcomeRejected:
+		return "rejected"
+	case OutcomeTransportError:
+		return "transport error"
+	case OutcomeError:
+		return "error"
+	case OutcomeCircuitOpen:
+		return "circuit open"
+	default:
+		return "unknown"
+	}
+}
+
+// PingEvent describes the result of a single ping, see [WithResultHook].
+type PingEvent struct {
+	// Signal is the signal which was sent.
+	Signal Signal
+	// Check is the redacted ping URL of the check, see [Check.Redacted].
+	Check string
+	// Duration is the time the signal took in total, including waiting for [WithRateLimit],
+	// retries of reset connections and trying other endpoints (see [WithURLs]).
+	Duration time.Duration
+	// Attempt is the number of times the request was sent, 0 if it wasn't sent at all.
+	// It counts retries and requests to all endpoints.
+	Attempt int
+	// StatusCode is the HTTP status of the response, 0 if none was received.
+	StatusCode int
+	// Outcome classifies the result: [OutcomeSuccess], [OutcomeSkipped], [OutcomeNotFound], [OutcomeRateLimited],
+	// [OutcomeRejected], [OutcomeTransportError], [OutcomeError] or [OutcomeCircuitOpen].
+	// With multiple endpoints, it is the outcome of the last request.
+	Outcome Outcome
+	// Err is the error returned to the caller.
+	Err error
+}
+
+// resultHook is called after each ping.
+type resultHook struct {
+	fn    func(PingEvent)
+	async bool
+}
+
+// notify calls all hooks with e.
+func notify(hooks []resultHook, e PingEvent) {
+	for _, h := range hooks {
+		if h.async {
+			go h.fn(e)
+		} else {
+			h.fn(e)
+		}
+	}
+}
+
+// responseOutcome classifies a response with the given status and body, where err is the result of
+// [Backend.CheckResponse].
+//
+// healthchecks.io may respond with 200 and a body of "OK (not found)" or "OK (rate limited)".
+func responseOutcome(status int, body []byte, err error) Outcome {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case status == http.StatusNotFound || bytes.Contains(body, []byte("(not found)")):
+		return OutcomeNotFound
+	case status == http.StatusTooManyRequests || bytes.Contains(body, []byte("(rate limited)")):
+		return OutcomeRateLimited
+	default:
+		return OutcomeRejected
+	}
+}
@@ -0,0 +1,174 @@
+package healthchecks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithResultHook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + testRedactUUID + "/fail":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found"))
+		case "/" + testRedactUUID + "/log":
+			_, _ = w.Write([]byte("OK (rate limited)"))
+		case "/" + testRedactUUID + "/1":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			_, _ = w.Write([]byte("OK"))
+		}
+	}))
+	defer srv.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close() // refuse connections
+
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		url         string
+		opts        []Option
+		send        func(c *Check) error
+		wantStatus  int
+		wantAttempt int
+		wantOutcome Outcome
+		wantErr     bool
+	}{
+		{
+			name:        "success",
+			url:         srv.URL,
+			send:        func(c *Check) error { return c.Success(ctx) },
+			wantStatus:  http.StatusOK,
+			wantAttempt: 1,
+			wantOutcome: OutcomeSuccess,
+		},
+		{
+			name:        "not found",
+			url:         srv.URL,
+			send:        func(c *Check) error { return c.Fail(ctx) },
+			wantStatus:  http.StatusNotFound,
+			wantAttempt: 1,
+			wantOutcome: OutcomeNotFound,
+			wantErr:     true,
+		},
+		{
+			name:        "rate limited",
+			url:         srv.URL,
+			send:        func(c *Check) error { return c.Log(ctx, "foo") },
+			wantStatus:  http.StatusOK,
+			wantAttempt: 1,
+			wantOutcome: OutcomeRateLimited,
+			wantErr:     true,
+		},
+		{
+			name:        "rejected",
+			url:         srv.URL,
+			send:        func(c *Check) error { return c.ExitStatus(ctx, 1) },
+			wantStatus:  http.StatusBadRequest,
+			wantAttempt: 1,
+			wantOutcome: OutcomeRejected,
+			wantErr:     true,
+		},
+		{
+			name:        "transport error",
+			url:         closed.URL,
+			send:        func(c *Check) error { return c.Success(ctx) },
+			wantAttempt: 1,
+			wantOutcome: OutcomeTransportError,
+			wantErr:     true,
+		},
+		{
+			name:        "disabled",
+			url:         srv.URL,
+			opts:        []Option{WithDisabled()},
+			send:        func(c *Check) error { return c.Success(ctx) },
+			wantOutcome: OutcomeSkipped,
+		},
+		{
+			name: "cancelled while rate limited",
+			url:  srv.URL,
+			opts: []Option{WithRateLimit(time.Hour, 1)},
+			send: func(c *Check) error {
+				_ = c.Log(ctx, "uses the only token")
+				cancelled, cancel := context.WithCancel(ctx)
+				cancel()
+				return c.Log(cancelled, "waits for a token")
+			},
+			wantOutcome: OutcomeError,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got PingEvent
+			opts := append([]Option{WithURL(tt.url), WithResultHook(func(e PingEvent) { got = e })}, tt.opts...)
+			c, err := NewUUID(testRedactUUID, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = tt.send(c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("signal error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !errors.Is(got.Err, err) {
+				t.Errorf("Err = %v, want %v", got.Err, err)
+			}
+			if want := tt.url + "/" + redacted; got.Check != want {
+				t.Errorf("Check = %s, want %s", got.Check, want)
+			}
+			if got.StatusCode != tt.wantStatus {
+				t.Errorf("StatusCode = %d, want %d", got.StatusCode, tt.wantStatus)
+			}
+			if got.Attempt != tt.wantAttempt {
+				t.Errorf("Attempt = %d, want %d", got.Attempt, tt.wantAttempt)
+			}
+			if got.Outcome != tt.wantOutcome {
+				t.Errorf("Outcome = %s, want %s", got.Outcome, tt.wantOutcome)
+			}
+		})
+	}
+}
+
+func TestWithAsyncResultHook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer srv.Close()
+
+	events := make(chan PingEvent, 1)
+	c, err := NewUUID(testRedactUUID, WithURL(srv.URL), WithAsyncResultHook(func(e PingEvent) {
+		events <- e
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	select {
+	case e := <-events:
+		if e.Signal != SignalStart || e.Outcome != OutcomeSuccess {
+			t.Errorf("event = %+v, want successful start", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("hook not called")
+	}
+
+	if _, err := NewUUID(testRedactUUID, WithResultHook(nil)); err == nil {
+		t.Error("NewUUID() error = nil, want error for nil hook")
+	}
+}
+
+func TestOutcomeZeroValue(t *testing.T) {
+	var e PingEvent
+	if e.Outcome == OutcomeSuccess {
+		t.Error("zero PingEvent has OutcomeSuccess")
+	}
+	if got := e.Outcome.String(); got != "unknown" {
+		t.Errorf("String() = %s, want unknown", got)
+	}
+}
@@ -23,7 +23,7 @@ func TestProjectEndpoints(t *testing.T) {
 		{
 			name: "valid",
 			p: &Project{
-				pingKey: config.PingKey,
+				pingKey: newSecret(config.PingKey),
 				opts: &options{
 					RootURL:    mustURL(config.URLPrefix),
 					HTTPClient: http.DefaultClient,
@@ -37,7 +37,7 @@ func TestProjectEndpoints(t *testing.T) {
 		{
 			name: "invalid URL",
 			p: &Project{
-				pingKey: config.PingKey,
+				pingKey: newSecret(config.PingKey),
 				opts: &options{
 					RootURL:    mustURL("https://example.com"),
 					HTTPClient: http.DefaultClient,
@@ -51,7 +51,7 @@ func TestProjectEndpoints(t *testing.T) {
 		{
 			name: "invalid ping key",
 			p: &Project{
-				pingKey: _pingKeyInvalid,
+				pingKey: newSecret(_pingKeyInvalid),
 				opts: &options{
 					RootURL:    mustURL(config.URLPrefix),
 					HTTPClient: http.DefaultClient,
@@ -65,7 +65,7 @@ func TestProjectEndpoints(t *testing.T) {
 		{
 			name: "invalid slug",
 			p: &Project{
-				pingKey: config.PingKey,
+				pingKey: newSecret(config.PingKey),
 				opts: &options{
 					RootURL:    mustURL(config.URLPrefix),
 					HTTPClient: http.DefaultClient,
@@ -112,7 +112,7 @@ func TestProjectLog(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &Project{
-				pingKey: config.PingKey,
+				pingKey: newSecret(config.PingKey),
 				opts: &options{
 					RootURL:    mustURL(config.URLPrefix),
 					HTTPClient: http.DefaultClient,
@@ -150,7 +150,7 @@ func TestProjectExitStatus(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &Project{
-				pingKey: config.PingKey,
+				pingKey: newSecret(config.PingKey),
 				opts: &options{
 					RootURL:    mustURL(config.URLPrefix),
 					HTTPClient: http.DefaultClient,
@@ -173,7 +173,7 @@ func TestCheckEndpoints(t *testing.T) {
 		{
 			name: "valid",
 			c: &Check{
-				path: config.UUID,
+				path: newSecret(config.UUID),
 				opts: &options{
 					RootURL:    mustURL(config.URLPrefix),
 					HTTPClient: http.DefaultClient,
@@ -184,7 +184,7 @@ func TestCheckEndpoints(t *testing.T) {
 		{
 			name: "invalid URL",
 			c: &Check{
-				path: config.UUID,
+				path: newSecret(config.UUID),
 				opts: &options{
 					RootURL:    mustURL("https://example.com"),
 					HTTPClient: http.DefaultClient,
@@ -195,7 +195,7 @@ func TestCheckEndpoints(t *testing.T) {
 		{
 			name: "invalid uuid",
 			c: &Check{
-				path: _uuidInvalid,
+				path: newSecret(_uuidInvalid),
 				opts: &options{
 					RootURL:    mustURL(config.URLPrefix),
 					HTTPClient: http.DefaultClient,
@@ -239,7 +239,7 @@ func TestCheckLog(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Check{
-				path: config.UUID,
+				path: newSecret(config.UUID),
 				opts: &options{
 					RootURL:    mustURL(config.URLPrefix),
 					HTTPClient: http.DefaultClient,
@@ -277,7 +277,7 @@ func TestCheckExitStatus(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Check{
-				path: config.UUID,
+				path: newSecret(config.UUID),
 				opts: &options{
 					RootURL:    mustURL(config.URLPrefix),
 					HTTPClient: http.DefaultClient,
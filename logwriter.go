@@ -0,0 +1,258 @@
+package healthchecks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFlushSize     = 10_000
+	defaultFlushInterval = 10 * time.Second
+	defaultFlushTimeout  = 30 * time.Second
+)
+
+// ErrWriterClosed is returned when writing to a log writer which has already been closed.
+var ErrWriterClosed = errors.New("log writer is closed")
+
+// LogWriterOption configures a writer created by [NewLogWriter].
+type LogWriterOption interface {
+	applyLogWriter(w *logWriter)
+}
+
+type flushSizeOption int
+
+var _ LogWriterOption = flushSizeOption(0)
+
+func (s flushSizeOption) applyLogWriter(w *logWriter) {
+	if s > 0 {
+		w.flushSize = int(s)
+	}
+}
+
+// WithFlushSize sets the number of buffered bytes after which the buffered lines are sent.
+// It is also the maximum size of a single message sent via [Notifier.Log].
+//
+// The default is 10000 bytes, which matches the default ping body limit of healthchecks.io.
+// Values <= 0 are ignored.
+func WithFlushSize(n int) LogWriterOption {
+	return flushSizeOption(n)
+}
+
+type flushIntervalOption time.Duration
+
+var _ LogWriterOption = flushIntervalOption(0)
+
+func (i flushIntervalOption) applyLogWriter(w *logWriter) {
+	w.flushInterval = time.Duration(i)
+}
+
+// WithFlushInterval sets the interval in which complete lines are sent, regardless of the buffer size.
+//
+// The default is 10s. Values <= 0 disable periodic flushing.
+func WithFlushInterval(d time.Duration) LogWriterOption {
+	return flushIntervalOption(d)
+}
+
+type flushContextOption struct {
+	ctx context.Context
+}
+
+var _ LogWriterOption = flushContextOption{}
+
+func (o flushContextOption) applyLogWriter(w *logWriter) {
+	if o.ctx != nil {
+		w.ctx = o.ctx
+	}
+}
+
+// WithFlushContext sets the context for sending the buffered data via [Notifier.Log].
+//
+// Once ctx is cancelled, sending fails, so remaining data is discarded. The default is [context.Background].
+func WithFlushContext(ctx context.Context) LogWriterOption {
+	return flushContextOption{ctx: ctx}
+}
+
+type flushTimeoutOption time.Duration
+
+var _ LogWriterOption = flushTimeoutOption(0)
+
+func (t flushTimeoutOption) applyLogWriter(w *logWriter) {
+	w.flushTimeout = time.Duration(t)
+}
+
+// WithFlushTimeout limits the time for sending a single message via [Notifier.Log], in addition to the timeout
+// of the check (see [WithTimeout]).
+//
+// The default is 30s. Values <= 0 disable the limit.
+func WithFlushTimeout(d time.Duration) LogWriterOption {
+	return flushTimeoutOption(d)
+}
+
+type logWriter struct {
+	n             Notifier
+	ctx           context.Context
+	flushSize     int
+	flushInterval time.Duration
+	flushTimeout  time.Duration
+
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	err    error // error of the last background flush, returned by the next call to Write or Close
+	closed bool
+
+	sendMu sync.Mutex // held while flushing, so concurrent flushes keep the order of messages
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewLogWriter creates a new [io.WriteCloser] which sends everything written to it to the check via [Notifier.Log].
+//
+// Writes are buffered line-wise. Complete lines are sent when the buffer exceeds the flush size
+// (see [WithFlushSize]) or when the flush interval elapses (see [WithFlushInterval]).
+// Calling Close sends all remaining data, including an incomplete last line.
+//
+// Errors occurring during a periodic flush are returned by the next call to Write or Close.
+func NewLogWriter(n Notifier, opts ...LogWriterOption) io.WriteCloser {
+	w := &logWriter{
+		n:             n,
+		ctx:           context.Background(),
+		flushSize:     defaultFlushSize,
+		flushInterval: defaultFlushInterval,
+		flushTimeout:  defaultFlushTimeout,
+		done:          make(chan struct{}),
+	}
+	for _, o := range opts {
+		o.applyLogWriter(w)
+	}
+
+	if w.flushInterval > 0 {
+		w.wg.Add(1)
+		go w.flushPeriodically()
+	}
+	return w
+}
+
+func (w *logWriter) flushPeriodically() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			if err := w.flush(false); err != nil {
+				w.mu.Lock()
+				w.err = err
+				w.mu.Unlock()
+			}
+		}
+	}
+}
+
+// Write implements [io.Writer].
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return 0, ErrWriterClosed
+	}
+
+	err := w.err
+	w.err = nil
+
+	w.buf.Write(p)
+	full := w.buf.Len() >= w.flushSize
+	w.mu.Unlock()
+
+	if full {
+		err = errors.Join(err, w.flush(false))
+	}
+	return len(p), err
+}
+
+// Close implements [io.Closer].
+//
+// It stops periodic flushing and sends all remaining data.
+func (w *logWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrWriterClosed
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	close(w.done)
+	w.wg.Wait()
+
+	err := w.flush(true)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return errors.Join(w.err, err)
+}
+
+// flush sends the buffered data in chunks of at most flushSize bytes, split at line boundaries where possible.
+//
+// If all is false, a trailing incomplete line is kept in the buffer unless it exceeds flushSize.
+// A chunk which could not be sent is discarded.
+//
+// Chunks are taken from the buffer while holding w.mu, but sent without it, so writes aren't blocked by slow requests.
+func (w *logWriter) flush(all bool) error {
+	w.sendMu.Lock()
+	defer w.sendMu.Unlock()
+
+	for {
+		w.mu.Lock()
+		msg, ok := w.next(all)
+		w.mu.Unlock()
+		if !ok {
+			return nil
+		}
+		if err := w.log(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// next removes the next chunk to send from the buffer, see [logWriter.flush].
+// ok is false if there is none.
+//
+// The caller must hold w.mu.
+func (w *logWriter) next(all bool) (msg string, ok bool) {
+	data := w.buf.Bytes()
+	if len(data) == 0 {
+		return "", false
+	}
+	chunk := data[:min(len(data), w.flushSize)]
+	if !all || len(chunk) < len(data) {
+		if i := bytes.LastIndexByte(chunk, '\n'); i >= 0 {
+			chunk = chunk[:i+1]
+		} else if !all && len(data) <= w.flushSize {
+			// only an incomplete line is left
+			return "", false
+		}
+	}
+
+	msg = string(chunk)
+	w.buf.Next(len(chunk))
+	return msg, true
+}
+
+// log sends msg via [Notifier.Log] using the context of the writer, limited by the flush timeout.
+func (w *logWriter) log(msg string) error {
+	ctx := w.ctx
+	if w.flushTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.flushTimeout)
+		defer cancel()
+	}
+	return w.n.Log(ctx, msg)
+}
@@ -0,0 +1,185 @@
+package healthchecks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingNotifier is a [Notifier] which records all messages sent via Log.
+type recordingNotifier struct {
+	mu     sync.Mutex
+	logs   []string
+	logErr error
+}
+
+func (r *recordingNotifier) Start(context.Context) error           { return nil }
+func (r *recordingNotifier) Success(context.Context) error         { return nil }
+func (r *recordingNotifier) Fail(context.Context) error            { return nil }
+func (r *recordingNotifier) ExitStatus(context.Context, int) error { return nil }
+
+func (r *recordingNotifier) Log(_ context.Context, msg string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, msg)
+	return r.logErr
+}
+
+func (r *recordingNotifier) messages() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.logs...)
+}
+
+func TestLogWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []LogWriterOption
+		writes []string
+		want   []string
+	}{
+		{
+			name:   "flush on close",
+			opts:   []LogWriterOption{WithFlushInterval(0)},
+			writes: []string{"foo\n", "bar"},
+			want:   []string{"foo\nbar"},
+		},
+		{
+			name:   "flush complete lines on size",
+			opts:   []LogWriterOption{WithFlushInterval(0), WithFlushSize(8)},
+			writes: []string{"foo\n", "bar\nba", "z"},
+			want:   []string{"foo\nbar\n", "baz"},
+		},
+		{
+			name:   "split long lines",
+			opts:   []LogWriterOption{WithFlushInterval(0), WithFlushSize(4)},
+			writes: []string{"abcdefghij"},
+			want:   []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name:   "empty",
+			opts:   []LogWriterOption{WithFlushInterval(0)},
+			writes: []string{},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := new(recordingNotifier)
+			w := NewLogWriter(n, tt.opts...)
+			for _, s := range tt.writes {
+				if _, err := io.WriteString(w, s); err != nil {
+					t.Fatalf("Write(%q) error = %v", s, err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if got := n.messages(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LogWriter messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogWriterInterval(t *testing.T) {
+	n := new(recordingNotifier)
+	w := NewLogWriter(n, WithFlushInterval(10*time.Millisecond))
+	defer w.Close()
+
+	if _, err := io.WriteString(w, "foo\nbar"); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(n.messages()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got, want := n.messages(), []string{"foo\n"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LogWriter messages = %q, want %q", got, want)
+	}
+}
+
+func TestLogWriterErrors(t *testing.T) {
+	wantErr := errors.New("foo")
+	n := &recordingNotifier{logErr: wantErr}
+	w := NewLogWriter(n, WithFlushInterval(0))
+
+	if _, err := io.WriteString(w, strings.Repeat("x", defaultFlushSize+1)); !errors.Is(err, wantErr) {
+		t.Errorf("Write() error = %v, want %v", err, wantErr)
+	}
+	n.mu.Lock()
+	n.logErr = nil
+	n.mu.Unlock()
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v, want nil", err)
+	}
+	if _, err := io.WriteString(w, "foo"); !errors.Is(err, ErrWriterClosed) {
+		t.Errorf("Write() after Close() error = %v, want %v", err, ErrWriterClosed)
+	}
+}
+
+// blockingNotifier blocks in Log until release is closed, reporting each call on started.
+type blockingNotifier struct {
+	recordingNotifier
+	started chan context.Context
+	release chan struct{}
+}
+
+func (b *blockingNotifier) Log(ctx context.Context, msg string) error {
+	b.started <- ctx
+	<-b.release
+	return b.recordingNotifier.Log(ctx, msg)
+}
+
+func TestLogWriterFlushUnlocked(t *testing.T) {
+	n := &blockingNotifier{started: make(chan context.Context, 1), release: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := NewLogWriter(n, WithFlushInterval(10*time.Millisecond), WithFlushContext(ctx), WithFlushTimeout(time.Minute))
+
+	if _, err := io.WriteString(w, "foo\n"); err != nil {
+		t.Fatal(err)
+	}
+	var logCtx context.Context
+	select {
+	case logCtx = <-n.started:
+	case <-time.After(time.Second):
+		t.Fatal("periodic flush not started")
+	}
+	if _, ok := logCtx.Deadline(); !ok {
+		t.Error("Log() context has no deadline")
+	}
+	cancel()
+	if logCtx.Err() == nil {
+		t.Error("Log() context not derived from flush context")
+	}
+
+	// writes aren't blocked by the pending flush
+	written := make(chan error, 1)
+	go func() {
+		_, err := io.WriteString(w, "bar\n")
+		written <- err
+	}()
+	select {
+	case err := <-written:
+		if err != nil {
+			t.Errorf("Write() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Write() blocked by pending flush")
+	}
+
+	close(n.release)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got, want := n.messages(), []string{"foo\n", "bar\n"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LogWriter messages = %q, want %q", got, want)
+	}
+}
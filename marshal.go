@@ -0,0 +1,176 @@
+package healthchecks
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// compile-time interface implementation check
+var (
+	_ encoding.TextMarshaler   = Check{}
+	_ encoding.TextUnmarshaler = (*Check)(nil)
+	_ json.Unmarshaler         = (*Check)(nil)
+	_ json.Unmarshaler         = (*CheckConfig)(nil)
+)
+
+// CheckConfig describes a check in a configuration file, in any of the forms accepted by [Check.UnmarshalJSON]
+// and [Check.UnmarshalYAML].
+//
+// Unlike [Check], the check is created by calling [CheckConfig.Check], which accepts additional options,
+// e.g. defaults of the configuration file.
+type CheckConfig struct {
+	// Text is a UUID, ping URL or DSN, see [Check.UnmarshalText]. The other fields need to be empty if it is set.
+	Text string `json:"-" yaml:"-"`
+	// UUID identifies the check by its UUID.
+	UUID string `json:"uuid" yaml:"uuid"`
+	// PingKey and Slug identify the check by its slug.
+	PingKey string `json:"ping_key" yaml:"ping_key"`
+	Slug    string `json:"slug" yaml:"slug"`
+	// URL is the root URL of a self-hosted instance, see [WithURL].
+	URL string `json:"url" yaml:"url"`
+}
+
+// Check creates the check described by cfg.
+//
+// opts are applied first, so the URL given by cfg takes precedence over [WithURL].
+func (cfg *CheckConfig) Check(opts ...Option) (*Check, error) {
+	if cfg.Text != "" {
+		if cfg.UUID != "" || cfg.PingKey != "" || cfg.Slug != "" || cfg.URL != "" {
+			return nil, errors.New("text and fields are mutually exclusive")
+		}
+		return checkFromString(cfg.Text, opts...)
+	}
+
+	if cfg.URL != "" {
+		opts = append(opts[:len(opts):len(opts)], WithURL(cfg.URL))
+	}
+	switch {
+	case cfg.UUID != "" && cfg.PingKey == "" && cfg.Slug == "":
+		return NewUUID(cfg.UUID, opts...)
+	case cfg.UUID == "" && cfg.PingKey != "" && cfg.Slug != "":
+		project, err := NewProject(cfg.PingKey, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if err := project.validateSlug(cfg.Slug); err != nil {
+			return nil, err
+		}
+		return project.Slug(cfg.Slug).(*Check), nil
+	default:
+		return nil, errors.New("either uuid or ping_key and slug are required")
+	}
+}
+
+// UnmarshalJSON implements [json.Unmarshaler], accepting a string or an object.
+func (cfg *CheckConfig) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*cfg = CheckConfig{Text: s}
+		return nil
+	}
+
+	type plain CheckConfig // without methods, avoiding recursion
+	var p plain
+	if err := json.Unmarshal(b, &p); err != nil {
+		return fmt.Errorf("decoding check: %w", err)
+	}
+	*cfg = CheckConfig(p)
+	return nil
+}
+
+// UnmarshalYAML accepts a string or an object in YAML documents.
+//
+// It implements the unmarshaler interface of gopkg.in/yaml.v2, which gopkg.in/yaml.v3 supports as well.
+func (cfg *CheckConfig) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		*cfg = CheckConfig{Text: s}
+		return nil
+	}
+
+	type plain CheckConfig // without methods, avoiding recursion
+	var p plain
+	if err := unmarshal(&p); err != nil {
+		return fmt.Errorf("decoding check: %w", err)
+	}
+	*cfg = CheckConfig(p)
+	return nil
+}
+
+// checkFromString creates a check from its UUID, ping URL or DSN.
+func checkFromString(s string, opts ...Option) (*Check, error) {
+	if !strings.Contains(s, "://") {
+		return NewUUID(s, opts...)
+	}
+	n, err := FromURL(s, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return n.(*Check), nil
+}
+
+// MarshalText implements [encoding.TextMarshaler], returning the redacted form (see [Check.Redacted]).
+//
+// Since secrets are redacted, the result can't be unmarshalled again.
+// It has a value receiver, so checks stored by value are encoded as well.
+func (c Check) MarshalText() ([]byte, error) {
+	return []byte(c.Redacted()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler], so checks can be loaded from configuration files.
+//
+// The text is either a UUID, a ping URL as accepted by [FromURL] or a DSN as accepted by [FromDSN].
+// Options enabled globally using environment variables apply (see [WithDisabled] and [WithDryRun]).
+func (c *Check) UnmarshalText(text []byte) error {
+	check, err := checkFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*c = *check
+	return nil
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+//
+// Besides strings as accepted by [Check.UnmarshalText], it accepts objects identifying the check by its UUID
+// or by ping key and slug, optionally with the URL of a self-hosted instance (see [WithURL]):
+//
+//	{"ping_key": "...", "slug": "backup", "url": "https://example.com/ping"}
+//	{"uuid": "..."}
+func (c *Check) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	var cfg CheckConfig
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return err
+	}
+	return c.fromConfig(&cfg)
+}
+
+// UnmarshalYAML accepts the same forms as [Check.UnmarshalJSON] in YAML documents.
+//
+// It implements the unmarshaler interface of gopkg.in/yaml.v2, which gopkg.in/yaml.v3 supports as well.
+func (c *Check) UnmarshalYAML(unmarshal func(any) error) error {
+	var cfg CheckConfig
+	if err := unmarshal(&cfg); err != nil {
+		return err
+	}
+	return c.fromConfig(&cfg)
+}
+
+func (c *Check) fromConfig(cfg *CheckConfig) error {
+	check, err := cfg.Check()
+	if err != nil {
+		return err
+	}
+	*c = *check
+	return nil
+}
@@ -0,0 +1,224 @@
+package healthchecks
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestCheckUnmarshal(t *testing.T) {
+	project, err := NewProject(testRedactPingKey, WithURL("https://example.com/ping"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slugCheck := project.Slug("backup").(*Check)
+	uuidCheck, err := NewUUID(testRedactUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		json    string
+		yaml    string
+		want    *Check
+		wantErr bool
+	}{
+		{
+			name: "uuid",
+			json: `"` + testRedactUUID + `"`,
+			yaml: testRedactUUID,
+			want: uuidCheck,
+		},
+		{
+			name: "url",
+			json: `"https://hc-ping.com/` + testRedactUUID + `"`,
+			yaml: "https://hc-ping.com/" + testRedactUUID,
+			want: uuidCheck,
+		},
+		{
+			name: "slug url",
+			json: `"https://example.com/ping/` + testRedactPingKey + `/backup"`,
+			yaml: "https://example.com/ping/" + testRedactPingKey + "/backup",
+			want: slugCheck,
+		},
+		{
+			name: "object with slug",
+			json: `{"ping_key": "` + testRedactPingKey + `", "slug": "backup", "url": "https://example.com/ping"}`,
+			yaml: "ping_key: " + testRedactPingKey + "\nslug: backup\nurl: https://example.com/ping",
+			want: slugCheck,
+		},
+		{
+			name: "object with uuid",
+			json: `{"uuid": "` + testRedactUUID + `"}`,
+			yaml: "uuid: " + testRedactUUID,
+			want: uuidCheck,
+		},
+		{
+			name:    "invalid uuid",
+			json:    `"foo"`,
+			yaml:    "foo",
+			wantErr: true,
+		},
+		{
+			name:    "object with uuid and slug",
+			json:    `{"uuid": "` + testRedactUUID + `", "slug": "backup"}`,
+			yaml:    "uuid: " + testRedactUUID + "\nslug: backup",
+			wantErr: true,
+		},
+		{
+			name:    "object without slug",
+			json:    `{"ping_key": "` + testRedactPingKey + `"}`,
+			yaml:    "ping_key: " + testRedactPingKey,
+			wantErr: true,
+		},
+		{
+			name:    "object with invalid slug",
+			json:    `{"ping_key": "` + testRedactPingKey + `", "slug": "Backup"}`,
+			yaml:    "ping_key: " + testRedactPingKey + "\nslug: Backup",
+			wantErr: true,
+		},
+		{
+			name:    "invalid type",
+			json:    `["` + testRedactUUID + `"]`,
+			yaml:    "[" + testRedactUUID + "]",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromJSON struct {
+				Check Check `json:"check"`
+			}
+			err := json.Unmarshal([]byte(`{"check": `+tt.json+`}`), &fromJSON)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(&fromJSON.Check, tt.want) {
+				t.Errorf("json.Unmarshal() = %#v, want %#v", fromJSON.Check, tt.want)
+			}
+
+			var fromYAML struct {
+				Check *Check `yaml:"check"`
+			}
+			err = yaml.Unmarshal([]byte("check:\n  "+strings.ReplaceAll(tt.yaml, "\n", "\n  ")), &fromYAML)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("yaml.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(fromYAML.Check, tt.want) {
+				t.Errorf("yaml.Unmarshal() = %#v, want %#v", fromYAML.Check, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMarshal(t *testing.T) {
+	project, err := NewProject(testRedactPingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := project.Slug("backup").(*Check)
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{
+			name: "pointer",
+			v: struct {
+				Check *Check `json:"check" yaml:"check"`
+			}{Check: check},
+		},
+		{
+			name: "value",
+			v: struct {
+				Check Check `json:"check" yaml:"check"`
+			}{Check: *check},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if got, want := string(b), `{"check":"https://hc-ping.com/****/backup"}`; got != want {
+				t.Errorf("json.Marshal() = %s, want %s", got, want)
+			}
+
+			b, err = yaml.Marshal(tt.v)
+			if err != nil {
+				t.Fatalf("yaml.Marshal() error = %v", err)
+			}
+			if got, want := string(b), "check: https://hc-ping.com/****/backup\n"; got != want {
+				t.Errorf("yaml.Marshal() = %s, want %s", got, want)
+			}
+		})
+	}
+
+	b, err := json.Marshal([]Check{*check})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if got, want := string(b), `["https://hc-ping.com/****/backup"]`; got != want {
+		t.Errorf("json.Marshal() of slice = %s, want %s", got, want)
+	}
+}
+
+func TestCheckConfigCheck(t *testing.T) {
+	defaults := []Option{WithURL("https://default.example.com")}
+	tests := []struct {
+		name    string
+		yaml    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "uuid with default URL",
+			yaml: testRedactUUID,
+			want: "https://default.example.com/****",
+		},
+		{
+			name: "object with default URL",
+			yaml: "uuid: " + testRedactUUID,
+			want: "https://default.example.com/****",
+		},
+		{
+			name: "url takes precedence",
+			yaml: "https://hc-ping.com/" + testRedactPingKey + "/backup",
+			want: "https://hc-ping.com/****/backup",
+		},
+		{
+			name: "object url takes precedence",
+			yaml: "ping_key: " + testRedactPingKey + "\nslug: backup\nurl: https://example.com/ping",
+			want: "https://example.com/ping/****/backup",
+		},
+		{
+			name:    "empty",
+			yaml:    "{}",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg CheckConfig
+			if err := yaml.Unmarshal([]byte(tt.yaml), &cfg); err != nil {
+				t.Fatalf("yaml.Unmarshal() error = %v", err)
+			}
+			check, err := cfg.Check(defaults...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckConfig.Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && check.Redacted() != tt.want {
+				t.Errorf("CheckConfig.Check() = %s, want %s", check.Redacted(), tt.want)
+			}
+		})
+	}
+
+	cfg := CheckConfig{Text: testRedactUUID, Slug: "backup"}
+	if _, err := cfg.Check(); err == nil {
+		t.Error("CheckConfig.Check() with text and fields error = nil, want error")
+	}
+}
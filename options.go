@@ -3,28 +3,49 @@ package healthchecks
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"time"
 )
 
 type options struct {
-	RootURL    *url.URL
-	HTTPClient *http.Client
+	RootURL          *url.URL
+	HTTPClient       *http.Client
+	Timeout          time.Duration
+	SkipValidation   bool
+	Create           bool
+	RunID            string
+	GracePeriod      time.Duration
+	Disabled         bool
+	DryRun           io.Writer
+	Limiter          *limiter
+	DisableKeepAlive bool
+	Concurrency      int
+	APIURL           *url.URL
+	ResultHooks      []resultHook
+	Breaker          *CircuitBreaker
+	Endpoints        *endpoints
 }
 
+// defaultTimeout limits each request, see [WithTimeout].
+const defaultTimeout = 10 * time.Second
+
 func defaultOptions() *options {
 	return &options{
 		RootURL: mustURL("https://hc-ping.com"),
 		HTTPClient: &http.Client{
-			Transport:     http.DefaultTransport,
+			Transport:     defaultTransport,
 			CheckRedirect: http.DefaultClient.CheckRedirect,
 			Jar:           http.DefaultClient.Jar,
-			Timeout:       10 * time.Second,
 		},
+		Timeout: defaultTimeout,
 	}
 }
 
+// optsFromDefaults applies opts to the defaults.
+//
+// Notifiers use [notifierOpts] instead, which also applies the options enabled using environment variables.
 func optsFromDefaults(opts []Option) (*options, error) {
 	options := defaultOptions()
 	for _, o := range opts {
@@ -45,24 +66,110 @@ type urlOption string
 var _ Option = urlOption("")
 
 func (u urlOption) apply(opts *options) error {
-	parsed, err := url.Parse(string(u))
+	parsed, err := parseRootURL(string(u))
 	if err != nil {
-		return fmt.Errorf("parse URL: %w", err)
-	}
-	if parsed.Scheme == "" || parsed.Host == "" {
-		return fmt.Errorf("invalid URL: %s", u)
+		return err
 	}
 	opts.RootURL = parsed
+	opts.Endpoints = nil
 	return nil
 }
 
+// setRootURL sets the root URL of the check's own URL (see [FromURL]), which is the primary endpoint
+// if failover endpoints are set via [WithURLs].
+func (o *options) setRootURL(u *url.URL) {
+	o.RootURL = u
+	if o.Endpoints != nil {
+		o.Endpoints = o.Endpoints.withPrimary(u)
+	}
+}
+
+// parseRootURL parses and validates the root URL of a ping endpoint.
+func parseRootURL(u string) (*url.URL, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("parse URL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid URL: %s", u)
+	}
+	return parsed, nil
+}
+
 // WithURL overrides the default URL https://hc-ping.com.
 //
 // The format should be http[s]://example.com[/suffix].
+//
+// It replaces the URLs set via [WithURLs].
 func WithURL(u string) Option {
 	return urlOption(u)
 }
 
+type urlsOption []string
+
+var _ Option = urlsOption{}
+
+func (u urlsOption) apply(opts *options) error {
+	if len(u) == 0 {
+		return errors.New("URLs must not be empty")
+	}
+	urls := make([]*url.URL, len(u))
+	for i, s := range u {
+		parsed, err := parseRootURL(s)
+		if err != nil {
+			return err
+		}
+		urls[i] = parsed
+	}
+	opts.RootURL = urls[0]
+	opts.Endpoints = nil
+	if len(urls) > 1 {
+		opts.Endpoints = newEndpoints(urls)
+	}
+	return nil
+}
+
+// WithURLs is like [WithURL], but accepts the URLs of multiple replicas in order of preference.
+//
+// Signals are sent to the last endpoint which responded, starting with the first one.
+// On transport errors and 5xx responses, the remaining endpoints are tried in order,
+// preferring endpoints which didn't fail on their last request.
+// The endpoints' health is shared by all checks of a [Project].
+//
+// The first URL is used for deriving the URL of the management API (see [WithAPIURL]) and for redaction.
+//
+// For checks created from a URL (see [FromURL], [FromDSN] and [FromEnv]), the check's URL is the primary endpoint,
+// followed by urls.
+func WithURLs(urls ...string) Option {
+	return urlsOption(urls)
+}
+
+type apiURLOption string
+
+var _ Option = apiURLOption("")
+
+func (u apiURLOption) apply(opts *options) error {
+	parsed, err := url.Parse(string(u))
+	if err != nil {
+		return fmt.Errorf("parse API URL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("invalid API URL: %s", u)
+	}
+	opts.APIURL = parsed
+	return nil
+}
+
+// WithAPIURL overrides the URL of the management API used by [APIClient].
+//
+// By default, it is derived from the ping URL (see [WithURL]): https://healthchecks.io for https://hc-ping.com,
+// otherwise the ping URL without a trailing /ping, matching the default setup of self-hosted instances.
+//
+// The format should be http[s]://example.com[/suffix], without /api/v3.
+func WithAPIURL(u string) Option {
+	return apiURLOption(u)
+}
+
 func mustURL(u string) *url.URL {
 	parsed, err := url.Parse(u)
 	if err != nil {
@@ -79,16 +186,17 @@ func (t timeoutOption) apply(opts *options) error {
 	if t < 0 {
 		return fmt.Errorf("timeout is %d, needs to be > 0", t)
 	}
-	opts.HTTPClient.Timeout = time.Duration(t)
+	opts.Timeout = time.Duration(t)
 	return nil
 }
 
-// WithTimeout sets the timeout for signalling requests (/start, ...).
+// WithTimeout sets the timeout for signalling requests (/start, ...), zero for no timeout.
+// It can be overridden per signal using [WithCallTimeout].
 //
 // The default is 10s.
 //
-// Note that you can also supply a timeout via [WithHTTPClient].
-// Whichever option is provided last will take precedence.
+// The timeout is applied to the context of each request, so the HTTP client isn't modified.
+// A timeout of a client set via [WithHTTPClient] applies in addition.
 func WithTimeout(t time.Duration) Option {
 	return timeoutOption(t)
 }
@@ -104,14 +212,282 @@ func (h httpClientOption) apply(opts *options) error {
 		return errors.New("HTTP client must be non-nil")
 	}
 	opts.HTTPClient = h.client
+	opts.Timeout = 0
 	return nil
 }
 
 // WithHTTPClient sets a custom HTTP client to be used during signalling requests.
 //
-// Default is [http.DefaultClient].
+// Default is a client with a dedicated transport, reusing keep-alive connections across all checks.
 //
-// Note that providing [WithTimeout] after [WithHTTPClient] will overwrite this client's timeout value.
+// The client is never modified. Requests are limited by the client's timeout only,
+// unless [WithTimeout] is provided after [WithHTTPClient].
 func WithHTTPClient(client *http.Client) Option {
 	return httpClientOption{client: client}
 }
+
+type skipValidationOption struct{}
+
+var _ Option = skipValidationOption{}
+
+func (skipValidationOption) apply(opts *options) error {
+	opts.SkipValidation = true
+	return nil
+}
+
+// WithoutValidation disables client-side validation of UUIDs, slugs and ping keys.
+//
+// By default, they are required to match the format used by healthchecks.io.
+// Use this for self-hosted instances with custom identifiers.
+func WithoutValidation() Option {
+	return skipValidationOption{}
+}
+
+type createOption struct{}
+
+var _ Option = createOption{}
+
+func (createOption) apply(opts *options) error {
+	opts.Create = true
+	return nil
+}
+
+// WithAutoCreate enables automatic creation of checks which are pinged by slug but don't exist yet.
+//
+// This appends ?create=1 to the ping URLs, see https://healthchecks.io/docs/autoprovisioning.
+// It has no effect on checks identified by UUID.
+func WithAutoCreate() Option {
+	return createOption{}
+}
+
+type runIDOption string
+
+var _ Option = runIDOption("")
+
+func (r runIDOption) apply(opts *options) error {
+	if err := validateUUID(string(r)); err != nil {
+		return fmt.Errorf("run ID: %w", err)
+	}
+	opts.RunID = string(r)
+	return nil
+}
+
+// WithRunID attaches a run ID to all signals (?rid=<uuid>).
+//
+// Healthchecks.io uses run IDs to match "start" signals with their corresponding "success" or "fail" signals,
+// see https://healthchecks.io/docs/measuring_script_run_time.
+// The run ID needs to be a UUID.
+func WithRunID(rid string) Option {
+	return runIDOption(rid)
+}
+
+type gracePeriodOption time.Duration
+
+var _ Option = gracePeriodOption(0)
+
+func (g gracePeriodOption) apply(opts *options) error {
+	if g <= 0 {
+		return fmt.Errorf("grace period is %d, needs to be > 0", g)
+	}
+	opts.GracePeriod = time.Duration(g)
+	return nil
+}
+
+// WithGuaranteedDelivery ensures that terminal signals ("success", "fail" and "exit-status") are delivered
+// even if the context passed by the caller is cancelled, e.g. because the job received SIGTERM or exceeded its deadline.
+//
+// These signals are sent using a context detached from the caller's cancellation, limited to the grace period instead.
+// If the caller's context has already ended, its cause is attached to the signal's body.
+// All other signals still obey the caller's context.
+func WithGuaranteedDelivery(grace time.Duration) Option {
+	return gracePeriodOption(grace)
+}
+
+type disabledOption struct{}
+
+var _ Option = disabledOption{}
+
+func (disabledOption) apply(opts *options) error {
+	opts.Disabled = true
+	return nil
+}
+
+// WithDisabled disables sending signals. All signals return nil without any network activity.
+//
+// This is useful for development environments.
+// It can also be enabled without recompiling by setting the environment variable HC_DISABLED=true
+// (or <PREFIX>_DISABLED for [FromEnv] with another prefix), which is read when creating a notifier.
+// Since environment variables are applied before the options passed to the constructor, they can't be
+// disabled in code.
+func WithDisabled() Option {
+	return disabledOption{}
+}
+
+type dryRunOption struct {
+	w io.Writer
+}
+
+var _ Option = dryRunOption{}
+
+func (d dryRunOption) apply(opts *options) error {
+	if d.w == nil {
+		return errors.New("dry run writer must be non-nil")
+	}
+	opts.DryRun = d.w
+	return nil
+}
+
+// WithDryRun prevents sending signals. Instead, the signal, URL and body of each request are written to w.
+// Secrets in the URL, i.e. UUIDs and ping keys, are redacted.
+// For checks of other backends (see [NewCheck]), the whole path is redacted.
+//
+// This is useful for development environments.
+// It can also be enabled without recompiling by setting the environment variable HC_DRY_RUN=true
+// (or <PREFIX>_DRY_RUN for [FromEnv] with another prefix), which writes to [os.Stderr].
+// A writer passed via this option replaces [os.Stderr].
+//
+// [WithDisabled] and HC_DISABLED take precedence over this option and HC_DRY_RUN.
+func WithDryRun(w io.Writer) Option {
+	return dryRunOption{w: w}
+}
+
+type rateLimitOption struct {
+	every time.Duration
+	burst int
+}
+
+var _ Option = rateLimitOption{}
+
+func (r rateLimitOption) apply(opts *options) error {
+	if r.every <= 0 {
+		return fmt.Errorf("rate limit interval is %d, needs to be > 0", r.every)
+	}
+	if r.burst < 1 {
+		return fmt.Errorf("rate limit burst is %d, needs to be >= 1", r.burst)
+	}
+	if opts.Limiter == nil {
+		opts.Limiter = newLimiter()
+	}
+	opts.Limiter.every = r.every
+	opts.Limiter.burst = r.burst
+	return nil
+}
+
+// WithRateLimit limits the rate of signals per check using a token bucket,
+// which holds up to burst tokens and gains one token per interval every.
+//
+// Redundant "success" signals (i.e. following a previous "success") are dropped if no token is available.
+// "log" signals wait for a token, respecting the context.
+// All other signals are always sent immediately.
+//
+// The limit is shared by all goroutines using the same [Check] or [Project].
+func WithRateLimit(every time.Duration, burst int) Option {
+	return rateLimitOption{every: every, burst: burst}
+}
+
+type coalescingOption time.Duration
+
+var _ Option = coalescingOption(0)
+
+func (c coalescingOption) apply(opts *options) error {
+	if c <= 0 {
+		return fmt.Errorf("coalescing window is %d, needs to be > 0", c)
+	}
+	if opts.Limiter == nil {
+		opts.Limiter = newLimiter()
+	}
+	opts.Limiter.window = time.Duration(c)
+	return nil
+}
+
+// WithCoalescing drops "success" signals which follow a previous "success" signal within window.
+//
+// This avoids hitting the rate limit of healthchecks.io when signalling success from high-frequency loops.
+// A "success" following any other signal is always sent.
+func WithCoalescing(window time.Duration) Option {
+	return coalescingOption(window)
+}
+
+type disableKeepAliveOption struct{}
+
+var _ Option = disableKeepAliveOption{}
+
+func (disableKeepAliveOption) apply(opts *options) error {
+	opts.DisableKeepAlive = true
+	return nil
+}
+
+// WithoutKeepAlive closes the connection after each request instead of reusing it.
+//
+// By default, keep-alive connections are reused, which avoids a TCP and TLS handshake per signal.
+// Connections reset by the server are retried transparently.
+func WithoutKeepAlive() Option {
+	return disableKeepAliveOption{}
+}
+
+type concurrencyOption int
+
+var _ Option = concurrencyOption(0)
+
+func (c concurrencyOption) apply(opts *options) error {
+	if c < 1 {
+		return fmt.Errorf("concurrency is %d, needs to be >= 1", c)
+	}
+	opts.Concurrency = int(c)
+	return nil
+}
+
+// WithConcurrency sets the maximum number of signals sent concurrently by [Project.SignalMany].
+//
+// The default is 8.
+func WithConcurrency(n int) Option {
+	return concurrencyOption(n)
+}
+
+type resultHookOption resultHook
+
+var _ Option = resultHookOption{}
+
+func (r resultHookOption) apply(opts *options) error {
+	if r.fn == nil {
+		return errors.New("result hook must be non-nil")
+	}
+	opts.ResultHooks = append(opts.ResultHooks, resultHook(r))
+	return nil
+}
+
+// WithResultHook calls hook after each ping with its result, e.g. for counting failures or
+// notifying through another channel.
+//
+// hook is called synchronously before the signalling method returns, so it should not block.
+// It can be provided multiple times, calling all hooks in order.
+func WithResultHook(hook func(PingEvent)) Option {
+	return resultHookOption{fn: hook}
+}
+
+// WithAsyncResultHook is like [WithResultHook], but calls hook in a new goroutine.
+func WithAsyncResultHook(hook func(PingEvent)) Option {
+	return resultHookOption{fn: hook, async: true}
+}
+
+type circuitBreakerOption struct {
+	breaker *CircuitBreaker
+}
+
+var _ Option = circuitBreakerOption{}
+
+func (c circuitBreakerOption) apply(opts *options) error {
+	if c.breaker == nil {
+		return errors.New("circuit breaker must be non-nil")
+	}
+	opts.Breaker = c.breaker
+	return nil
+}
+
+// WithCircuitBreaker fails signals with [ErrCircuitOpen] instead of sending them while cb is open.
+//
+// The state of cb is shared by all checks of a [Project] and all other checks cb is passed to,
+// so pass the same breaker to all checks pinging the same endpoint.
+func WithCircuitBreaker(cb *CircuitBreaker) Option {
+	return circuitBreakerOption{breaker: cb}
+}
@@ -65,6 +65,13 @@ func TestWithTimeout(t *testing.T) {
 	type args struct {
 		opts *options
 	}
+	// the client must not be modified, since it might be shared
+	client := &http.Client{
+		Transport:     http.DefaultTransport,
+		CheckRedirect: http.DefaultClient.CheckRedirect,
+		Jar:           http.DefaultClient.Jar,
+		Timeout:       30 * time.Second,
+	}
 	tests := []struct {
 		name     string
 		timeout  time.Duration
@@ -73,55 +80,25 @@ func TestWithTimeout(t *testing.T) {
 		wantErr  bool
 	}{
 		{
-			name:    "valid",
-			timeout: 5 * time.Second,
-			args: args{opts: &options{HTTPClient: &http.Client{
-				Transport:     http.DefaultTransport,
-				CheckRedirect: http.DefaultClient.CheckRedirect,
-				Jar:           http.DefaultClient.Jar,
-				Timeout:       10 * time.Second,
-			}}},
-			wantOpts: &options{HTTPClient: &http.Client{
-				Transport:     http.DefaultTransport,
-				CheckRedirect: http.DefaultClient.CheckRedirect,
-				Jar:           http.DefaultClient.Jar,
-				Timeout:       5 * time.Second,
-			}},
-			wantErr: false,
+			name:     "valid",
+			timeout:  5 * time.Second,
+			args:     args{opts: &options{HTTPClient: client, Timeout: 10 * time.Second}},
+			wantOpts: &options{HTTPClient: client, Timeout: 5 * time.Second},
+			wantErr:  false,
 		},
 		{
-			name:    "zero",
-			timeout: 0 * time.Second,
-			args: args{opts: &options{HTTPClient: &http.Client{
-				Transport:     http.DefaultTransport,
-				CheckRedirect: http.DefaultClient.CheckRedirect,
-				Jar:           http.DefaultClient.Jar,
-				Timeout:       10 * time.Second,
-			}}},
-			wantOpts: &options{HTTPClient: &http.Client{
-				Transport:     http.DefaultTransport,
-				CheckRedirect: http.DefaultClient.CheckRedirect,
-				Jar:           http.DefaultClient.Jar,
-				Timeout:       0 * time.Second,
-			}},
-			wantErr: false,
+			name:     "zero",
+			timeout:  0 * time.Second,
+			args:     args{opts: &options{HTTPClient: client, Timeout: 10 * time.Second}},
+			wantOpts: &options{HTTPClient: client, Timeout: 0 * time.Second},
+			wantErr:  false,
 		},
 		{
-			name:    "negative",
-			timeout: -1 * time.Second,
-			args: args{opts: &options{HTTPClient: &http.Client{
-				Transport:     http.DefaultTransport,
-				CheckRedirect: http.DefaultClient.CheckRedirect,
-				Jar:           http.DefaultClient.Jar,
-				Timeout:       10 * time.Second,
-			}}},
-			wantOpts: &options{HTTPClient: &http.Client{
-				Transport:     http.DefaultTransport,
-				CheckRedirect: http.DefaultClient.CheckRedirect,
-				Jar:           http.DefaultClient.Jar,
-				Timeout:       10 * time.Second,
-			}},
-			wantErr: true,
+			name:     "negative",
+			timeout:  -1 * time.Second,
+			args:     args{opts: &options{HTTPClient: client, Timeout: 10 * time.Second}},
+			wantOpts: &options{HTTPClient: client, Timeout: 10 * time.Second},
+			wantErr:  true,
 		},
 	}
 	for _, tt := range tests {
@@ -134,6 +111,9 @@ func TestWithTimeout(t *testing.T) {
 			if !reflect.DeepEqual(tt.args.opts, tt.wantOpts) {
 				t.Errorf("WithTimeout(%s).apply() result mismatch:\ngot =  %#v\nwant = %#v", tt.timeout, tt.args.opts, tt.wantOpts)
 			}
+			if client.Timeout != 30*time.Second {
+				t.Errorf("WithTimeout(%s).apply() modified the HTTP client's timeout to %s", tt.timeout, client.Timeout)
+			}
 		})
 	}
 }
@@ -222,3 +202,115 @@ func TestWithHTTPClient(t *testing.T) {
 		})
 	}
 }
+
+func TestWithRunID(t *testing.T) {
+	tests := []struct {
+		name     string
+		rid      string
+		wantOpts *options
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			rid:      "0b1c1d2e-3f40-4152-8637-48495a6b7c8d",
+			wantOpts: &options{RunID: "0b1c1d2e-3f40-4152-8637-48495a6b7c8d"},
+			wantErr:  false,
+		},
+		{
+			name:     "invalid",
+			rid:      "foo",
+			wantOpts: &options{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &options{}
+			if err := WithRunID(tt.rid).apply(opts); (err != nil) != tt.wantErr {
+				t.Errorf("WithRunID(%s).apply() error = %v, wantErr %v", tt.rid, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(opts, tt.wantOpts) {
+				t.Errorf("WithRunID(%s).apply() result mismatch:\ngot =  %#v\nwant = %#v", tt.rid, opts, tt.wantOpts)
+			}
+		})
+	}
+}
+
+func TestWithGuaranteedDelivery(t *testing.T) {
+	tests := []struct {
+		name     string
+		grace    time.Duration
+		wantOpts *options
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			grace:    5 * time.Second,
+			wantOpts: &options{GracePeriod: 5 * time.Second},
+			wantErr:  false,
+		},
+		{
+			name:     "zero",
+			grace:    0,
+			wantOpts: &options{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &options{}
+			if err := WithGuaranteedDelivery(tt.grace).apply(opts); (err != nil) != tt.wantErr {
+				t.Errorf("WithGuaranteedDelivery(%s).apply() error = %v, wantErr %v", tt.grace, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(opts, tt.wantOpts) {
+				t.Errorf("WithGuaranteedDelivery(%s).apply() result mismatch:\ngot =  %#v\nwant = %#v", tt.grace, opts, tt.wantOpts)
+			}
+		})
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		every     time.Duration
+		burst     int
+		wantEvery time.Duration
+		wantBurst int
+		wantErr   bool
+	}{
+		{
+			name:      "valid",
+			every:     time.Second,
+			burst:     5,
+			wantEvery: time.Second,
+			wantBurst: 5,
+			wantErr:   false,
+		},
+		{
+			name:    "zero interval",
+			every:   0,
+			burst:   5,
+			wantErr: true,
+		},
+		{
+			name:    "zero burst",
+			every:   time.Second,
+			burst:   0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &options{}
+			if err := WithRateLimit(tt.every, tt.burst).apply(opts); (err != nil) != tt.wantErr {
+				t.Errorf("WithRateLimit(%s, %d).apply() error = %v, wantErr %v", tt.every, tt.burst, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts.Limiter == nil || opts.Limiter.every != tt.wantEvery || opts.Limiter.burst != tt.wantBurst {
+				t.Errorf("WithRateLimit(%s, %d).apply() result mismatch: got %#v", tt.every, tt.burst, opts.Limiter)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/stnokott/healthchecks"
+)
+
+// defaultTimeout is the default duration after which a probe is considered failed.
+const defaultTimeout = 10 * time.Second
+
+// Aggregator runs multiple probes concurrently and reports their combined result to a single check.
+//
+// Use [NewAggregator] for obtaining a new instance.
+type Aggregator struct {
+	notifier healthchecks.Notifier
+	opts     *options
+
+	mu     sync.Mutex
+	probes []probe
+}
+
+type probe struct {
+	name    string
+	checker Checker
+	timeout time.Duration
+}
+
+// Result is the outcome of a single probe.
+type Result struct {
+	// Name is the name the probe has been added with.
+	Name string
+	// Err is the error returned by the probe, nil if it passed.
+	Err error
+	// Duration is the time the probe took.
+	Duration time.Duration
+}
+
+// NewAggregator creates a new instance of [Aggregator], reporting to n.
+func NewAggregator(n healthchecks.Notifier, opts ...Option) (*Aggregator, error) {
+	if n == nil {
+		return nil, errors.New("notifier must not be nil")
+	}
+	options := &options{
+		Timeout: defaultTimeout,
+	}
+	for _, o := range opts {
+		if err := o.apply(options); err != nil {
+			return nil, fmt.Errorf("applying option: %w", err)
+		}
+	}
+	return &Aggregator{
+		notifier: n,
+		opts:     options,
+	}, nil
+}
+
+// Add adds the probe c, identified by name in the report.
+func (a *Aggregator) Add(name string, c Checker, opts ...ProbeOption) error {
+	if name == "" {
+		return errors.New("probe name must not be empty")
+	}
+	if c == nil {
+		return errors.New("checker must not be nil")
+	}
+	p := probe{
+		name:    name,
+		checker: c,
+		timeout: a.opts.Timeout,
+	}
+	for _, o := range opts {
+		if err := o.applyProbe(&p); err != nil {
+			return fmt.Errorf("applying probe option: %w", err)
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, existing := range a.probes {
+		if existing.name == name {
+			return fmt.Errorf("duplicate probe name '%s'", name)
+		}
+	}
+	a.probes = append(a.probes, p)
+	return nil
+}
+
+// Run runs all probes concurrently and reports the result to the check:
+// "success" if all probes pass, "fail" with a table of all probes attached otherwise.
+//
+// The results are returned in the order the probes have been added.
+// The returned error is only non-nil if the result could not be reported.
+func (a *Aggregator) Run(ctx context.Context) ([]Result, error) {
+	results := a.check(ctx)
+	failed := false
+	for _, r := range results {
+		if r.Err != nil {
+			failed = true
+			break
+		}
+	}
+	if !failed {
+		return results, a.notifier.Success(ctx)
+	}
+	return results, healthchecks.FailWithBody(ctx, a.notifier, formatResults(results))
+}
+
+// check runs all probes concurrently.
+func (a *Aggregator) check(ctx context.Context) []Result {
+	a.mu.Lock()
+	probes := append([]probe(nil), a.probes...)
+	a.mu.Unlock()
+
+	results := make([]Result, len(probes))
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func(i int, p probe) {
+			defer wg.Done()
+			started := time.Now()
+			err := p.run(ctx)
+			results[i] = Result{
+				Name:     p.name,
+				Err:      err,
+				Duration: time.Since(started),
+			}
+		}(i, p)
+	}
+	wg.Wait()
+	return results
+}
+
+// run runs the probe, returning once its timeout expires even if the checker doesn't respect ctx.
+func (p probe) run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- p.checker.Check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %s", p.timeout)
+		}
+		return ctx.Err()
+	}
+}
+
+// formatResults formats results as a table, one row per probe.
+func formatResults(results []Result) string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "PROBE\tSTATUS\tDURATION\tERROR")
+	for _, r := range results {
+		status, msg := "OK", ""
+		if r.Err != nil {
+			status, msg = "FAIL", strings.ReplaceAll(r.Err.Error(), "\n", " ")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, status, r.Duration.Round(time.Millisecond), msg)
+	}
+	_ = w.Flush()
+	return sb.String()
+}
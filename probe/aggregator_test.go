@@ -0,0 +1,188 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingNotifier records all signals in the order they are received.
+type recordingNotifier struct {
+	mu      sync.Mutex
+	signals []string
+}
+
+func (r *recordingNotifier) record(s string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.signals = append(r.signals, s)
+	return nil
+}
+
+func (r *recordingNotifier) Start(context.Context) error   { return r.record("start") }
+func (r *recordingNotifier) Success(context.Context) error { return r.record("success") }
+func (r *recordingNotifier) Fail(context.Context) error    { return r.record("fail") }
+func (r *recordingNotifier) Log(_ context.Context, msg string) error {
+	return r.record("log:" + msg)
+}
+func (r *recordingNotifier) ExitStatus(context.Context, int) error { return r.record("exit-status") }
+
+func passing(context.Context) error {
+	return nil
+}
+
+func failing(context.Context) error {
+	return errors.New("broken")
+}
+
+func TestAggregatorRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		probes     map[string]CheckerFunc
+		wantErrs   []bool
+		wantSignal string
+	}{
+		{
+			name:       "all pass",
+			probes:     map[string]CheckerFunc{"a": passing, "b": passing},
+			wantErrs:   []bool{false, false},
+			wantSignal: "success",
+		},
+		{
+			name:       "one fails",
+			probes:     map[string]CheckerFunc{"a": passing, "b": failing},
+			wantErrs:   []bool{false, true},
+			wantSignal: "fail",
+		},
+		{
+			name:       "no probes",
+			probes:     map[string]CheckerFunc{},
+			wantErrs:   []bool{},
+			wantSignal: "success",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &recordingNotifier{}
+			a, err := NewAggregator(n)
+			if err != nil {
+				t.Fatalf("NewAggregator() error = %v", err)
+			}
+			for _, name := range []string{"a", "b"} {
+				if c, ok := tt.probes[name]; ok {
+					if err := a.Add(name, c); err != nil {
+						t.Fatalf("Add() error = %v", err)
+					}
+				}
+			}
+
+			results, err := a.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			gotErrs := make([]bool, len(results))
+			for i, r := range results {
+				gotErrs[i] = r.Err != nil
+			}
+			if !reflect.DeepEqual(gotErrs, tt.wantErrs) {
+				t.Errorf("result errors = %v, want %v", gotErrs, tt.wantErrs)
+			}
+			if got := n.signals[len(n.signals)-1]; got != tt.wantSignal {
+				t.Errorf("signal = %s, want %s", got, tt.wantSignal)
+			}
+		})
+	}
+}
+
+func TestAggregatorReport(t *testing.T) {
+	n := &recordingNotifier{}
+	a, err := NewAggregator(n)
+	if err != nil {
+		t.Fatalf("NewAggregator() error = %v", err)
+	}
+	if err := a.Add("database", CheckerFunc(passing)); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Add("disk", CheckerFunc(failing)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(n.signals) != 2 || n.signals[1] != "fail" {
+		t.Fatalf("signals = %v, want log and fail", n.signals)
+	}
+	lines := strings.Split(strings.TrimPrefix(n.signals[0], "log:"), "\n")
+	if !strings.HasPrefix(lines[0], "PROBE") ||
+		!strings.HasPrefix(lines[1], "database  OK") ||
+		!strings.HasPrefix(lines[2], "disk      FAIL") || !strings.HasSuffix(lines[2], "broken") {
+		t.Errorf("unexpected report:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestAggregatorTimeout(t *testing.T) {
+	n := &recordingNotifier{}
+	a, err := NewAggregator(n, WithTimeout(time.Hour))
+	if err != nil {
+		t.Fatalf("NewAggregator() error = %v", err)
+	}
+	block := make(chan struct{})
+	defer close(block)
+	// ignores the context
+	stuck := CheckerFunc(func(context.Context) error {
+		<-block
+		return nil
+	})
+	if err := a.Add("stuck", stuck, WithProbeTimeout(10*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := a.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if results[0].Err == nil || !strings.Contains(results[0].Err.Error(), "timed out") {
+		t.Errorf("result error = %v, want timeout", results[0].Err)
+	}
+}
+
+func TestAggregatorAddInvalid(t *testing.T) {
+	a, err := NewAggregator(&recordingNotifier{})
+	if err != nil {
+		t.Fatalf("NewAggregator() error = %v", err)
+	}
+	if err := a.Add("a", CheckerFunc(passing)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		probe   string
+		checker Checker
+		opts    []ProbeOption
+	}{
+		{name: "empty name", probe: "", checker: CheckerFunc(passing)},
+		{name: "duplicate name", probe: "a", checker: CheckerFunc(passing)},
+		{name: "nil checker", probe: "b", checker: nil},
+		{name: "invalid timeout", probe: "c", checker: CheckerFunc(passing), opts: []ProbeOption{WithProbeTimeout(0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := a.Add(tt.probe, tt.checker, tt.opts...); err == nil {
+				t.Error("Add() error = nil, want error")
+			}
+		})
+	}
+
+	if _, err := NewAggregator(nil); err == nil {
+		t.Error("NewAggregator(nil) error = nil, want error")
+	}
+	if _, err := NewAggregator(&recordingNotifier{}, WithTimeout(-1)); err == nil {
+		t.Error("NewAggregator() with invalid timeout error = nil, want error")
+	}
+}
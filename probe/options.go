@@ -0,0 +1,56 @@
+package probe
+
+import (
+	"fmt"
+	"time"
+)
+
+type options struct {
+	Timeout time.Duration
+}
+
+// Option applies a configuration option to an [Aggregator].
+type Option interface {
+	apply(opts *options) error
+}
+
+type timeoutOption time.Duration
+
+var _ Option = timeoutOption(0)
+
+func (t timeoutOption) apply(opts *options) error {
+	if t <= 0 {
+		return fmt.Errorf("timeout is %d, needs to be > 0", t)
+	}
+	opts.Timeout = time.Duration(t)
+	return nil
+}
+
+// WithTimeout sets the default timeout of each probe.
+//
+// Defaults to 10 seconds. It can be overridden per probe using [WithProbeTimeout].
+func WithTimeout(t time.Duration) Option {
+	return timeoutOption(t)
+}
+
+// ProbeOption applies a configuration option to a single probe added via [Aggregator.Add].
+type ProbeOption interface {
+	applyProbe(p *probe) error
+}
+
+type probeTimeoutOption time.Duration
+
+var _ ProbeOption = probeTimeoutOption(0)
+
+func (t probeTimeoutOption) applyProbe(p *probe) error {
+	if t <= 0 {
+		return fmt.Errorf("timeout is %d, needs to be > 0", t)
+	}
+	p.timeout = time.Duration(t)
+	return nil
+}
+
+// WithProbeTimeout overrides the timeout set by [WithTimeout] for a single probe.
+func WithProbeTimeout(t time.Duration) ProbeOption {
+	return probeTimeoutOption(t)
+}
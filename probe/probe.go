@@ -0,0 +1,182 @@
+// Package probe provides local health probes, which can be aggregated into a single healthchecks.io check.
+package probe
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Checker is a single health probe.
+type Checker interface {
+	// Check returns a non-nil error if the probed resource is unhealthy.
+	//
+	// It should return once ctx is done.
+	Check(ctx context.Context) error
+}
+
+// compile-time interface implementation check
+var _ Checker = CheckerFunc(nil)
+
+// CheckerFunc is an adapter to allow the use of ordinary functions as [Checker].
+type CheckerFunc func(ctx context.Context) error
+
+// Check implements [Checker] by calling f.
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// maxDrainSize limits the number of bytes of a response body read by [HTTPGet].
+// Connections with longer responses aren't reused.
+const maxDrainSize = 64 << 10
+
+// HTTPGet returns a [Checker] which sends a GET request to url, expecting a 2xx response status.
+//
+// Redirects are followed. If client is nil, [http.DefaultClient] is used.
+func HTTPGet(url string, client *http.Client) Checker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return CheckerFunc(func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return fmt.Errorf("creating request: %w", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("requesting: %w", err)
+		}
+		// read the body, so the connection can be reused
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainSize))
+		_ = resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("HTTP response status %d", resp.StatusCode)
+		}
+		return nil
+	})
+}
+
+// TCPDial returns a [Checker] which opens a TCP connection to addr (host:port) and closes it immediately.
+func TCPDial(addr string) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	})
+}
+
+// Pinger is implemented by [*sql.DB] and [*sql.Conn].
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+var _ Pinger = (*sql.DB)(nil)
+
+// SQLPing returns a [Checker] which verifies the connection to a database, usually a [*sql.DB].
+func SQLPing(db Pinger) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("pinging database: %w", err)
+		}
+		return nil
+	})
+}
+
+// DiskSpace returns a [Checker] which fails if the file system containing path has less than minFree bytes
+// available to unprivileged users.
+//
+// It returns [errors.ErrUnsupported] on platforms other than unix.
+func DiskSpace(path string, minFree uint64) Checker {
+	return CheckerFunc(func(context.Context) error {
+		free, err := diskFree(path)
+		if err != nil {
+			return fmt.Errorf("determining free disk space: %w", err)
+		}
+		if free < minFree {
+			return fmt.Errorf("%s free, need %s", formatBytes(free), formatBytes(minFree))
+		}
+		return nil
+	})
+}
+
+// formatBytes formats n using binary prefixes, e.g. "1.5 GiB".
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return strconv.FormatUint(n, 10) + " B"
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// FileFreshness returns a [Checker] which fails if the file at path doesn't exist
+// or hasn't been modified within maxAge, e.g. a backup or the output of a cron job.
+func FileFreshness(path string, maxAge time.Duration) Checker {
+	return CheckerFunc(func(context.Context) error {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if age := time.Since(info.ModTime()); age > maxAge {
+			return fmt.Errorf("last modified %s ago, max. %s", age.Round(time.Second), maxAge)
+		}
+		return nil
+	})
+}
+
+// ProcessAlive returns a [Checker] which fails if the process with the ID stored in pidFile isn't running.
+//
+// It returns [errors.ErrUnsupported] on platforms other than unix.
+func ProcessAlive(pidFile string) Checker {
+	return CheckerFunc(func(context.Context) error {
+		b, err := os.ReadFile(pidFile)
+		if err != nil {
+			return fmt.Errorf("reading PID file: %w", err)
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+		if err != nil || pid <= 0 {
+			return errors.New("PID file does not contain a valid process ID")
+		}
+		return processAlive(pid)
+	})
+}
+
+// maxCommandOutput is the maximum number of trailing bytes of a failed command's output included in the error.
+const maxCommandOutput = 1000
+
+// Command returns a [Checker] which runs the command name with args, failing if it exits with a non-zero code.
+//
+// The error includes the end of the command's combined output.
+// The command is killed once ctx is done.
+func Command(name string, args ...string) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+		if err == nil {
+			return nil
+		}
+		output := strings.TrimSpace(string(out))
+		if len(output) > maxCommandOutput {
+			output = "..." + output[len(output)-maxCommandOutput:]
+		}
+		if output == "" {
+			return err
+		}
+		return fmt.Errorf("%w: %s", err, output)
+	})
+}
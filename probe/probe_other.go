@@ -0,0 +1,13 @@
+//go:build !unix
+
+package probe
+
+import "errors"
+
+func diskFree(string) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
+
+func processAlive(int) error {
+	return errors.ErrUnsupported
+}
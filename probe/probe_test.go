@@ -0,0 +1,220 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, _ := strconv.Atoi(r.URL.Query().Get("status"))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		status  int
+		wantErr bool
+	}{
+		{status: 200, wantErr: false},
+		{status: 204, wantErr: false},
+		{status: 404, wantErr: true},
+		{status: 503, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.status), func(t *testing.T) {
+			err := HTTPGet(srv.URL+"?status="+strconv.Itoa(tt.status), nil).Check(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPGetReusesConnections(t *testing.T) {
+	var conns atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, strings.Repeat("ok\n", 1000))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	checker := HTTPGet(srv.URL, srv.Client())
+	for i := 0; i < 3; i++ {
+		if err := checker.Check(context.Background()); err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+	}
+	if n := conns.Load(); n != 1 {
+		t.Errorf("opened %d connections, want 1", n)
+	}
+}
+
+func TestTCPDial(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+
+	if err := TCPDial(addr).Check(context.Background()); err != nil {
+		t.Errorf("Check() with listener error = %v", err)
+	}
+	_ = l.Close()
+	if err := TCPDial(addr).Check(context.Background()); err == nil {
+		t.Error("Check() without listener error = nil, want error")
+	}
+}
+
+type fakePinger struct {
+	err error
+}
+
+func (p fakePinger) PingContext(context.Context) error {
+	return p.err
+}
+
+func TestSQLPing(t *testing.T) {
+	if err := SQLPing(fakePinger{}).Check(context.Background()); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+	errDown := errors.New("connection refused")
+	if err := SQLPing(fakePinger{err: errDown}).Check(context.Background()); !errors.Is(err, errDown) {
+		t.Errorf("Check() error = %v, want %v", err, errDown)
+	}
+}
+
+func TestDiskSpace(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("not supported")
+	}
+	dir := t.TempDir()
+	if err := DiskSpace(dir, 1).Check(context.Background()); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+	if err := DiskSpace(dir, math.MaxUint64).Check(context.Background()); err == nil {
+		t.Error("Check() with excessive threshold error = nil, want error")
+	}
+	if err := DiskSpace(filepath.Join(dir, "missing"), 1).Check(context.Background()); err == nil {
+		t.Error("Check() with missing path error = nil, want error")
+	}
+}
+
+func TestFileFreshness(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.tar")
+	if err := FileFreshness(path, time.Hour).Check(context.Background()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Check() error = %v, want %v", err, os.ErrNotExist)
+	}
+
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := FileFreshness(path, time.Hour).Check(context.Background()); err != nil {
+		t.Errorf("Check() with fresh file error = %v", err)
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := FileFreshness(path, time.Hour).Check(context.Background()); err == nil {
+		t.Error("Check() with old file error = nil, want error")
+	}
+}
+
+func TestProcessAlive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("not supported")
+	}
+	dir := t.TempDir()
+	writePID := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		pidFile string
+		wantErr bool
+	}{
+		{name: "running", pidFile: writePID("self.pid", strconv.Itoa(os.Getpid())+"\n"), wantErr: false},
+		{name: "not running", pidFile: writePID("gone.pid", "2147483646"), wantErr: true},
+		{name: "invalid", pidFile: writePID("invalid.pid", "foo"), wantErr: true},
+		{name: "missing", pidFile: filepath.Join(dir, "missing.pid"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ProcessAlive(tt.pidFile).Check(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{n: 0, want: "0 B"},
+		{n: 1023, want: "1023 B"},
+		{n: 1024, want: "1.0 KiB"},
+		{n: 1536 * 1024 * 1024, want: "1.5 GiB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("formatBytes(%d) = %s, want %s", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+	}{
+		{name: "success", script: "exit 0", wantErr: ""},
+		{name: "failure", script: "exit 3", wantErr: "exit status 3"},
+		{name: "failure with output", script: "echo disk full; exit 1", wantErr: "exit status 1: disk full"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Command("sh", "-c", tt.script).Check(context.Background())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Check() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Check() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
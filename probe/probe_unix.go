@@ -0,0 +1,27 @@
+//go:build unix
+
+package probe
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+)
+
+func diskFree(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil // field types differ between platforms
+}
+
+func processAlive(pid int) error {
+	// signal 0 only performs error checking
+	err := syscall.Kill(pid, 0)
+	if err == nil || errors.Is(err, syscall.EPERM) {
+		// EPERM: running, but owned by a different user
+		return nil
+	}
+	return fmt.Errorf("process %d is not running: %w", pid, err)
+}
@@ -0,0 +1,161 @@
+package healthchecks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter limits the rate of signals per check using a token bucket.
+// Additionally, it coalesces redundant "success" signals.
+//
+// It is safe for concurrent use.
+type limiter struct {
+	every  time.Duration // interval in which a token is added, 0 to disable rate limiting
+	burst  int           // maximum number of tokens
+	window time.Duration // "success" signals within this window after a previous "success" are dropped
+
+	now func() time.Time // defaults to time.Now if nil
+
+	mu     sync.Mutex
+	checks map[string]*limiterState
+	pruned time.Time // time of the last call of prune
+}
+
+type limiterState struct {
+	tokens      float64
+	refilled    time.Time
+	lastSuccess time.Time // zero if the last signal sent to the check wasn't "success"
+}
+
+func newLimiter() *limiter {
+	return &limiter{
+		checks: map[string]*limiterState{},
+	}
+}
+
+// state returns the state of the check identified by key with refilled tokens.
+//
+// The caller must hold l.mu.
+func (l *limiter) state(key string) *limiterState {
+	now := time.Now()
+	if l.now != nil {
+		now = l.now()
+	}
+	l.prune(now)
+	s, ok := l.checks[key]
+	if !ok {
+		s = &limiterState{
+			tokens:   float64(l.burst),
+			refilled: now,
+		}
+		l.checks[key] = s
+	}
+	if l.every > 0 {
+		s.tokens = min(float64(l.burst), s.tokens+float64(now.Sub(s.refilled))/float64(l.every))
+	}
+	s.refilled = now
+	return s
+}
+
+// idle returns the duration after which the state of an unused check doesn't differ from a new one,
+// i.e. the coalescing window has passed and all tokens are refilled.
+func (l *limiter) idle() time.Duration {
+	return max(l.window, time.Duration(l.burst)*l.every)
+}
+
+// prune removes the states of checks which have been idle for longer than [limiter.idle],
+// so a [Project] with many slugs doesn't grow the map indefinitely. It sweeps at most once per idle duration.
+//
+// The caller must hold l.mu.
+func (l *limiter) prune(now time.Time) {
+	idle := l.idle()
+	if now.Sub(l.pruned) < idle {
+		return
+	}
+	l.pruned = now
+	for key, s := range l.checks {
+		if now.Sub(s.refilled) > idle {
+			delete(l.checks, key)
+		}
+	}
+}
+
+// take removes a token from s, reporting whether one was available.
+// It always succeeds if rate limiting is disabled.
+func (l *limiter) take(s *limiterState) bool {
+	if l.every <= 0 {
+		return true
+	}
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+// acquire decides whether sig may be sent to the check identified by key:
+//
+//   - "success" signals are dropped (returning false) if the previous signal was "success" as well and
+//     either was sent within the coalescing window or no token is available.
+//   - "log" signals wait for a token, respecting ctx.
+//   - all other signals are always allowed immediately.
+//
+// If allowed, the returned function needs to be called with the result of sending the signal.
+func (l *limiter) acquire(ctx context.Context, key string, sig Signal) (bool, func(error), error) {
+	for {
+		l.mu.Lock()
+		s := l.state(key)
+		now := s.refilled
+
+		switch sig.kind {
+		case kindSuccess:
+			redundant := !s.lastSuccess.IsZero()
+			if redundant && now.Sub(s.lastSuccess) < l.window {
+				l.mu.Unlock()
+				return false, nil, nil
+			}
+			if !l.take(s) && redundant {
+				l.mu.Unlock()
+				return false, nil, nil
+			}
+			s.lastSuccess = now
+			l.mu.Unlock()
+			return true, func(err error) {
+				if err != nil {
+					l.resetSuccess(key, now)
+				}
+			}, nil
+		case kindLog:
+			if l.take(s) {
+				l.mu.Unlock()
+				return true, func(error) {}, nil
+			}
+			wait := time.Duration((1 - s.tokens) * float64(l.every))
+			l.mu.Unlock()
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return false, nil, ctx.Err()
+			case <-timer.C:
+			}
+		default:
+			l.take(s)
+			s.lastSuccess = time.Time{}
+			l.mu.Unlock()
+			return true, func(error) {}, nil
+		}
+	}
+}
+
+// resetSuccess forgets a "success" signal acquired at t which could not be sent,
+// so the next "success" signal isn't coalesced with it.
+func (l *limiter) resetSuccess(key string, t time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if s, ok := l.checks[key]; ok && s.lastSuccess.Equal(t) {
+		s.lastSuccess = time.Time{}
+	}
+}
@@ -0,0 +1,171 @@
+package healthchecks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiterAcquire(t *testing.T) {
+	type step struct {
+		after   time.Duration // time elapsed since the previous step
+		sig     Signal
+		sendErr error
+		want    bool
+	}
+	tests := []struct {
+		name   string
+		every  time.Duration
+		burst  int
+		window time.Duration
+		steps  []step
+	}{
+		{
+			name:   "coalesce successes within window",
+			window: time.Minute,
+			steps: []step{
+				{after: 0, sig: SignalSuccess, want: true},
+				{after: time.Second, sig: SignalSuccess, want: false},
+				{after: 30 * time.Second, sig: SignalSuccess, want: false},
+				{after: 30 * time.Second, sig: SignalSuccess, want: true},
+			},
+		},
+		{
+			name:   "success after other signal",
+			window: time.Minute,
+			steps: []step{
+				{after: 0, sig: SignalSuccess, want: true},
+				{after: time.Second, sig: SignalFail, want: true},
+				{after: time.Second, sig: SignalSuccess, want: true},
+				{after: time.Second, sig: SignalStart, want: true},
+				{after: time.Second, sig: SignalSuccess, want: true},
+			},
+		},
+		{
+			name:   "failed success is not coalesced",
+			window: time.Minute,
+			steps: []step{
+				{after: 0, sig: SignalSuccess, sendErr: errors.New("foo"), want: true},
+				{after: time.Second, sig: SignalSuccess, want: true},
+			},
+		},
+		{
+			name:  "rate limit drops redundant successes",
+			every: 10 * time.Second,
+			burst: 2,
+			steps: []step{
+				{after: 0, sig: SignalSuccess, want: true},
+				{after: 0, sig: SignalSuccess, want: true},
+				{after: 0, sig: SignalSuccess, want: false},
+				{after: 0, sig: SignalFail, want: true},
+				{after: 0, sig: SignalSuccess, want: true},
+				{after: 0, sig: SignalExitStatus(1), want: true},
+				{after: 0, sig: SignalStart, want: true},
+				{after: 10 * time.Second, sig: SignalSuccess, want: true},
+				{after: 0, sig: SignalSuccess, want: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			l := newLimiter()
+			l.every, l.burst, l.window = tt.every, tt.burst, tt.window
+			l.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.after)
+				got, done, err := l.acquire(context.Background(), "foo", s.sig)
+				if err != nil {
+					t.Fatalf("step %d: acquire() error = %v", i, err)
+				}
+				if got != s.want {
+					t.Errorf("step %d: acquire(%s) = %v, want %v", i, s.sig, got, s.want)
+				}
+				if got {
+					done(s.sendErr)
+				}
+			}
+		})
+	}
+}
+
+func TestLimiterPrune(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newLimiter()
+	l.every, l.burst, l.window = time.Second, 5, time.Minute
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 100; i++ {
+		if _, _, err := l.acquire(context.Background(), fmt.Sprintf("slug-%d", i), SignalSuccess); err != nil {
+			t.Fatalf("acquire() error = %v", err)
+		}
+	}
+	now = now.Add(30 * time.Second)
+	if _, _, err := l.acquire(context.Background(), "slug-0", SignalSuccess); err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	if n := len(l.checks); n != 100 {
+		t.Errorf("%d checks within window, want 100", n)
+	}
+
+	// slug-0 was used within the window
+	now = now.Add(45 * time.Second)
+	if _, _, err := l.acquire(context.Background(), "other", SignalSuccess); err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	if _, ok := l.checks["slug-0"]; !ok || len(l.checks) != 2 {
+		t.Errorf("checks = %d after window, want slug-0 and other", len(l.checks))
+	}
+}
+
+func TestLimiterLogWaits(t *testing.T) {
+	l := newLimiter()
+	l.every, l.burst = 20*time.Millisecond, 1
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		allowed, done, err := l.acquire(context.Background(), "foo", SignalLog)
+		if err != nil || !allowed {
+			t.Fatalf("acquire() = %v, %v, want true, nil", allowed, err)
+		}
+		done(nil)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("3 log signals with burst 1 took %s, want >= 40ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := l.acquire(ctx, "foo", SignalLog); !errors.Is(err, context.Canceled) {
+		t.Errorf("acquire() with cancelled context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestLimiterConcurrent(t *testing.T) {
+	l := newLimiter()
+	l.every, l.burst, l.window = time.Hour, 5, time.Hour
+
+	var sent atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			allowed, done, _ := l.acquire(context.Background(), "foo", SignalSuccess)
+			if allowed {
+				sent.Add(1)
+				done(nil)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := sent.Load(); got != 1 {
+		t.Errorf("%d concurrent successes sent, want 1", got)
+	}
+}
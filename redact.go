@@ -0,0 +1,136 @@
+package healthchecks
+
+import (
+	"fmt"
+	"io"
+	"path"
+)
+
+// secret holds a string behind a pointer, so printing a [Check] or [Project] value, which doesn't use
+// their Format methods, only reveals its address.
+type secret struct {
+	s *string
+}
+
+func newSecret(s string) secret {
+	return secret{s: &s}
+}
+
+// value returns the secret, empty for the zero value.
+func (s secret) value() string {
+	if s.s == nil {
+		return ""
+	}
+	return *s.s
+}
+
+// Redacted returns the ping URL of the project with its ping key replaced by asterisks, e.g. https://hc-ping.com/****.
+func (p *Project) Redacted() string {
+	if p.opts == nil {
+		return ""
+	}
+	return p.opts.RootURL.JoinPath(redacted).String()
+}
+
+// String returns the same as [Project.Redacted].
+func (p *Project) String() string {
+	return p.Redacted()
+}
+
+// GoString implements [fmt.GoStringer], returning the redacted form.
+func (p *Project) GoString() string {
+	return fmt.Sprintf("healthchecks.Project(%q)", p.Redacted())
+}
+
+// Format implements [fmt.Formatter], so the ping key is never printed, regardless of the verb.
+func (p *Project) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, p.Redacted(), p.GoString())
+}
+
+// Redacted returns the ping URL of the check with its secret, i.e. the UUID or ping key, replaced by asterisks,
+// e.g. https://hc-ping.com/****/slug.
+//
+// For checks of other backends (see [NewCheck]), it returns the result of the backend's Redacted method if available.
+func (c *Check) Redacted() string {
+	if c.backend == nil && c.opts == nil {
+		return ""
+	}
+	return redactedCheck(c.getBackend())
+}
+
+// redactedCheck returns the redacted URL of the check pinged via b, see [Check.Redacted].
+func redactedCheck(b Backend) string {
+	switch b := b.(type) {
+	case *healthchecksBackend:
+		return b.redacted().checkURL().String()
+	case interface{ Redacted() string }:
+		return b.Redacted()
+	default:
+		return fmt.Sprintf("%T", b)
+	}
+}
+
+// String returns the same as [Check.Redacted].
+func (c *Check) String() string {
+	return c.Redacted()
+}
+
+// GoString implements [fmt.GoStringer], returning the redacted form.
+func (c *Check) GoString() string {
+	return fmt.Sprintf("healthchecks.Check(%q)", c.Redacted())
+}
+
+// Format implements [fmt.Formatter], so secrets are never printed, regardless of the verb.
+func (c *Check) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, c.Redacted(), c.GoString())
+}
+
+// Redacted returns the push URL with the push token replaced by asterisks.
+func (b UptimeKumaBackend) Redacted() string {
+	if b.pushURL == nil {
+		return ""
+	}
+	u := *b.pushURL
+	u.Path = path.Join(path.Dir(u.Path), redacted)
+	u.RawPath = u.Path // keep asterisks unescaped
+	return u.String()
+}
+
+// String returns the same as [UptimeKumaBackend.Redacted].
+func (b UptimeKumaBackend) String() string {
+	return b.Redacted()
+}
+
+// Format implements [fmt.Formatter], so the push token is never printed, regardless of the verb.
+func (b UptimeKumaBackend) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, b.Redacted(), fmt.Sprintf("healthchecks.UptimeKumaBackend(%q)", b.Redacted()))
+}
+
+// Redacted returns scheme and host of the success template with the path redacted.
+func (b TemplateBackend) Redacted() string {
+	u, err := parseURL(b.templates.Success)
+	if err != nil {
+		return ""
+	}
+	u.RawQuery, u.Fragment = "", ""
+	return redactAllPath(u).String()
+}
+
+// String returns the same as [TemplateBackend.Redacted].
+func (b TemplateBackend) String() string {
+	return b.Redacted()
+}
+
+// Format implements [fmt.Formatter], so the templates are never printed, regardless of the verb.
+func (b TemplateBackend) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, b.Redacted(), fmt.Sprintf("healthchecks.TemplateBackend(%q)", b.Redacted()))
+}
+
+// formatRedacted formats s according to verb, or writes goString for %#v.
+func formatRedacted(f fmt.State, verb rune, s, goString string) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, goString)
+		return
+	}
+	_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), s)
+}
@@ -0,0 +1,142 @@
+package healthchecks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testRedactUUID    = "6da9bc25-880d-4a73-a0e5-e833405e206f"
+	testRedactPingKey = "rk9bbOJREu6nOWeHGjlnDQ"
+	testRunID         = "0b0ad5e3-1c5e-4b8e-9a3f-2f1d4c6b7a89"
+)
+
+func TestRedacted(t *testing.T) {
+	project, err := NewProject(testRedactPingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check, err := NewUUID(testRedactUUID, WithURL("https://example.com/ping"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	kuma, err := NewUptimeKumaBackend("https://kuma.example.com/api/push/s3cr3tt0k3n?status=up")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kumaCheck, err := NewCheck(kuma)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := NewTemplateBackend(URLTemplates{Success: "https://cronitor.link/p/s3cr3tt0k3n/job?state=complete"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		v      fmt.Formatter
+		want   string
+		secret string
+	}{
+		{name: "project", v: project, want: "https://hc-ping.com/****", secret: testRedactPingKey},
+		{name: "slug", v: project.Slug("sample-check").(*Check), want: "https://hc-ping.com/****/sample-check", secret: testRedactPingKey},
+		{name: "uuid", v: check, want: "https://example.com/ping/****", secret: testRedactUUID},
+		{name: "uptime kuma", v: kuma, want: "https://kuma.example.com/api/push/****", secret: "s3cr3tt0k3n"},
+		{name: "uptime kuma check", v: kumaCheck, want: "https://kuma.example.com/api/push/****", secret: "s3cr3tt0k3n"},
+		{name: "template", v: tmpl, want: "https://cronitor.link/****", secret: "s3cr3tt0k3n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprint(tt.v); got != tt.want {
+				t.Errorf("Sprint() = %s, want %s", got, tt.want)
+			}
+			for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%d", "%x", "%10.3s"} {
+				if got := fmt.Sprintf(verb, tt.v); strings.Contains(got, tt.secret) {
+					t.Errorf("Sprintf(%s) = %s, contains secret", verb, got)
+				}
+			}
+		})
+	}
+
+	if got, want := fmt.Sprintf("%#v", check), `healthchecks.Check("https://example.com/ping/****")`; got != want {
+		t.Errorf("Sprintf(%%#v) = %s, want %s", got, want)
+	}
+	if got := (&Check{}).Redacted(); got != "" {
+		t.Errorf("Redacted() of zero value = %s, want empty", got)
+	}
+
+	// values don't implement fmt.Formatter, but their secrets are redacted nevertheless
+	values := []struct {
+		name   string
+		v      any
+		secret string
+	}{
+		{name: "check value", v: *check, secret: testRedactUUID},
+		{name: "slug value", v: *project.Slug("sample-check").(*Check), secret: testRedactPingKey},
+		{name: "project value", v: *project, secret: testRedactPingKey},
+	}
+	for _, tt := range values {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x"} {
+				if got := fmt.Sprintf(verb, tt.v); strings.Contains(got, tt.secret) {
+					t.Errorf("Sprintf(%s) = %s, contains secret", verb, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestErrorRedacted(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srvURL := srv.URL
+	srv.Close() // refuse connections
+
+	project, err := NewProject(testRedactPingKey, WithURL(srvURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check, err := NewUUID(testRedactUUID, WithURL(srvURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewAPIClient("apikey", WithAPIURL(srvURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		err     error
+		wantURL string
+	}{
+		{name: "slug", err: project.Fail(ctx, "sample-check"), wantURL: srvURL + "/****/sample-check/fail"},
+		{name: "uuid", err: check.Send(ctx, SignalStart, WithCallRunID(testRunID)), wantURL: srvURL + "/****/start?rid=" + testRunID},
+		{name: "api", err: func() error {
+			_, err := client.ListFlips(ctx, testRedactUUID, time.Time{})
+			return err
+		}(), wantURL: srvURL + "/api/v3/checks/****/flips/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var urlErr *url.Error
+			if !errors.As(tt.err, &urlErr) {
+				t.Fatalf("error = %v, want *url.Error", tt.err)
+			}
+			if urlErr.URL != tt.wantURL {
+				t.Errorf("URL = %s, want %s", urlErr.URL, tt.wantURL)
+			}
+			if msg := tt.err.Error(); strings.Contains(msg, testRedactPingKey) || strings.Contains(msg, testRedactUUID) {
+				t.Errorf("error = %v, contains secret", tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package report
+
+import (
+	"errors"
+	"time"
+)
+
+type options struct {
+	Tags     []string
+	Location *time.Location
+}
+
+func defaultOptions() *options {
+	return &options{
+		Location: time.UTC,
+	}
+}
+
+// Option applies a configuration option to [Generate].
+type Option interface {
+	apply(opts *options) error
+}
+
+type tagsOption []string
+
+var _ Option = tagsOption(nil)
+
+func (t tagsOption) apply(opts *options) error {
+	opts.Tags = append(opts.Tags, t...)
+	return nil
+}
+
+// WithTags only includes checks having all of tags.
+func WithTags(tags ...string) Option {
+	return tagsOption(tags)
+}
+
+type locationOption struct {
+	loc *time.Location
+}
+
+var _ Option = locationOption{}
+
+func (l locationOption) apply(opts *options) error {
+	if l.loc == nil {
+		return errors.New("location must not be nil")
+	}
+	opts.Location = l.loc
+	return nil
+}
+
+// WithLocation sets the time zone in which days start for the availability per day.
+//
+// Defaults to UTC.
+func WithLocation(loc *time.Location) Option {
+	return locationOption{loc: loc}
+}
@@ -0,0 +1,187 @@
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+// reportJSON is the JSON representation of [Report].
+type reportJSON struct {
+	Since  time.Time         `json:"since"`
+	Until  time.Time         `json:"until"`
+	Checks []checkReportJSON `json:"checks"`
+}
+
+type checkReportJSON struct {
+	Name                 string       `json:"name"`
+	Slug                 string       `json:"slug"`
+	ID                   string       `json:"id"`
+	Tags                 []string     `json:"tags"`
+	MonitoredSeconds     float64      `json:"monitored_seconds"`
+	DowntimeSeconds      float64      `json:"downtime_seconds"`
+	UptimePercent        *float64     `json:"uptime_percent"`
+	OutageCount          int          `json:"outage_count"`
+	MTTRSeconds          float64      `json:"mttr_seconds"`
+	LongestOutageSeconds float64      `json:"longest_outage_seconds"`
+	Outages              []outageJSON `json:"outages"`
+	Days                 []dayJSON    `json:"days"`
+}
+
+type outageJSON struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Ongoing bool      `json:"ongoing"`
+}
+
+type dayJSON struct {
+	Date             string   `json:"date"`
+	MonitoredSeconds float64  `json:"monitored_seconds"`
+	UptimePercent    *float64 `json:"uptime_percent"`
+}
+
+// percentJSON returns nil for NaN, which JSON can't represent.
+func percentJSON(p float64) *float64 {
+	if math.IsNaN(p) {
+		return nil
+	}
+	return &p
+}
+
+// WriteJSON writes r as JSON to w. Uptimes of checks which weren't monitored are null.
+func (r *Report) WriteJSON(w io.Writer) error {
+	out := reportJSON{
+		Since:  r.Since,
+		Until:  r.Until,
+		Checks: make([]checkReportJSON, 0, len(r.Checks)),
+	}
+	for i := range r.Checks {
+		c := &r.Checks[i]
+		cj := checkReportJSON{
+			Name:                 c.Name,
+			Slug:                 c.Slug,
+			ID:                   c.ID,
+			Tags:                 c.Tags,
+			MonitoredSeconds:     c.Monitored.Seconds(),
+			DowntimeSeconds:      c.Downtime.Seconds(),
+			UptimePercent:        percentJSON(c.Uptime),
+			OutageCount:          len(c.Outages),
+			MTTRSeconds:          c.MTTR.Seconds(),
+			LongestOutageSeconds: c.LongestOutage.Seconds(),
+			Outages:              make([]outageJSON, 0, len(c.Outages)),
+			Days:                 make([]dayJSON, 0, len(c.Days)),
+		}
+		if cj.Tags == nil {
+			cj.Tags = []string{}
+		}
+		for _, o := range c.Outages {
+			cj.Outages = append(cj.Outages, outageJSON(o))
+		}
+		for _, d := range c.Days {
+			cj.Days = append(cj.Days, dayJSON{
+				Date:             d.Date.Format(dateFormat),
+				MonitoredSeconds: d.Monitored.Seconds(),
+				UptimePercent:    percentJSON(d.Uptime),
+			})
+		}
+		out.Checks = append(out.Checks, cj)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// WriteCSV writes r as CSV to w, one row per check with a column per day.
+//
+// Durations are in seconds, uptimes in percent. Uptimes of checks which weren't monitored are empty.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{
+		"name", "slug", "id", "tags", "uptime_percent", "monitored_seconds", "downtime_seconds",
+		"outages", "mttr_seconds", "longest_outage_seconds",
+	}
+	if len(r.Checks) > 0 {
+		for _, d := range r.Checks[0].Days {
+			header = append(header, d.Date.Format(dateFormat))
+		}
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i := range r.Checks {
+		c := &r.Checks[i]
+		row := []string{
+			c.Name,
+			c.Slug,
+			c.ID,
+			strings.Join(c.Tags, " "),
+			formatPercent(c.Uptime),
+			formatSeconds(c.Monitored),
+			formatSeconds(c.Downtime),
+			strconv.Itoa(len(c.Outages)),
+			formatSeconds(c.MTTR),
+			formatSeconds(c.LongestOutage),
+		}
+		for _, d := range c.Days {
+			row = append(row, formatPercent(d.Uptime))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteMarkdown writes a summary of r as Markdown table to w.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(bw, "Uptime from %s to %s\n\n", r.Since.Format(time.RFC3339), r.Until.Format(time.RFC3339))
+	_, _ = fmt.Fprintln(bw, "| Check | Uptime | Outages | MTTR | Longest outage | Downtime |")
+	_, _ = fmt.Fprintln(bw, "| ----- | -----: | ------: | ---: | -------------: | -------: |")
+	for i := range r.Checks {
+		c := &r.Checks[i]
+		uptime := "n/a"
+		if !math.IsNaN(c.Uptime) {
+			uptime = formatPercent(c.Uptime) + " %"
+		}
+		_, _ = fmt.Fprintf(bw, "| %s | %s | %d | %s | %s | %s |\n",
+			markdownEscaper.Replace(c.Name),
+			uptime,
+			len(c.Outages),
+			formatDuration(c.MTTR),
+			formatDuration(c.LongestOutage),
+			formatDuration(c.Downtime),
+		)
+	}
+	return bw.Flush()
+}
+
+var markdownEscaper = strings.NewReplacer(`|`, `\|`, "\n", " ")
+
+func formatPercent(p float64) string {
+	if math.IsNaN(p) {
+		return ""
+	}
+	return strconv.FormatFloat(p, 'f', 3, 64)
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testReport() *Report {
+	return &Report{
+		Since: testSince,
+		Until: testUntil,
+		Checks: []CheckReport{
+			{
+				Name:          "Backup | nightly",
+				Slug:          "backup",
+				ID:            "backup-id",
+				Tags:          []string{"prod", "db"},
+				Monitored:     48 * time.Hour,
+				Downtime:      3 * time.Hour,
+				Uptime:        93.75,
+				Outages:       []Outage{{Start: at(10), End: at(11)}, {Start: at(46), End: at(48), Ongoing: true}},
+				MTTR:          time.Hour,
+				LongestOutage: 2 * time.Hour,
+				Days: []Day{
+					{Date: at(0), Monitored: 24 * time.Hour, Uptime: 95.5},
+					{Date: at(24), Monitored: 24 * time.Hour, Uptime: 92},
+				},
+			},
+			{
+				Name:   "New",
+				Slug:   "new",
+				ID:     "new-id",
+				Uptime: math.NaN(),
+				Days: []Day{
+					{Date: at(0), Uptime: math.NaN()},
+					{Date: at(24), Uptime: math.NaN()},
+				},
+			},
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testReport().WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := "name,slug,id,tags,uptime_percent,monitored_seconds,downtime_seconds,outages,mttr_seconds,longest_outage_seconds,2024-01-01,2024-01-02\n" +
+		"Backup | nightly,backup,backup-id,prod db,93.750,172800,10800,2,3600,7200,95.500,92.000\n" +
+		"New,new,new-id,,,0,0,0,0,0,,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteCSV() = \n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testReport().WriteMarkdown(&buf); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	want := "Uptime from 2024-01-01T00:00:00Z to 2024-01-03T00:00:00Z\n\n" +
+		"| Check | Uptime | Outages | MTTR | Longest outage | Downtime |\n" +
+		"| ----- | -----: | ------: | ---: | -------------: | -------: |\n" +
+		"| Backup \\| nightly | 93.750 % | 2 | 1h0m0s | 2h0m0s | 3h0m0s |\n" +
+		"| New | n/a | 0 | - | - | - |\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteMarkdown() = \n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testReport().WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var got reportJSON
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("decoding %s: %v", buf.String(), err)
+	}
+	if len(got.Checks) != 2 {
+		t.Fatalf("got %d checks, want 2", len(got.Checks))
+	}
+	backup, newCheck := got.Checks[0], got.Checks[1]
+	if backup.UptimePercent == nil || *backup.UptimePercent != 93.75 || backup.OutageCount != 2 || backup.MTTRSeconds != 3600 {
+		t.Errorf("Backup = %+v", backup)
+	}
+	if !backup.Outages[1].Ongoing || backup.Days[1].Date != "2024-01-02" {
+		t.Errorf("Backup outages, days = %+v, %+v", backup.Outages, backup.Days)
+	}
+	if newCheck.UptimePercent != nil || newCheck.Days[0].UptimePercent != nil {
+		t.Errorf("uptimes of unmonitored check = %v, %v, want null", newCheck.UptimePercent, newCheck.Days[0].UptimePercent)
+	}
+	if !reflect.DeepEqual(newCheck.Tags, []string{}) || newCheck.Outages == nil {
+		t.Errorf("empty lists = %v, %v, want []", newCheck.Tags, newCheck.Outages)
+	}
+}
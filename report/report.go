@@ -0,0 +1,322 @@
+// Package report computes uptime and SLA figures of checks from their flip history.
+//
+// A flip is a change of the status of a check between up and down, see [healthchecks.Flip].
+// Time a check was paused or hadn't received its first ping yet is not monitored and excluded from the uptime.
+//
+// The management API reports pausing a check as a flip to down. A flip to down is recognized as pause if
+// the check was down already, if it is the last flip of a currently paused check, or if the logged pings
+// show the check wasn't overdue, i.e. the last ping before the flip was no failure and the next one wasn't
+// due yet. Pings are only available with read-write API keys and only the most recent ones are kept, so
+// without them, earlier pauses of checks which were up count as downtime.
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/stnokott/healthchecks"
+)
+
+// Report contains the figures of multiple checks for a time range.
+type Report struct {
+	// Since is the start of the time range.
+	Since time.Time
+	// Until is the end of the time range.
+	Until time.Time
+	// Checks contains the figures per check, ordered by name.
+	Checks []CheckReport
+}
+
+// CheckReport contains the figures of a single check.
+type CheckReport struct {
+	// Name is the name of the check.
+	Name string
+	// Slug is the slug of the check.
+	Slug string
+	// ID is the unique key of the check (see [healthchecks.CheckInfo.UniqueKey]), which doesn't reveal its UUID.
+	ID string
+	// Tags are the tags of the check.
+	Tags []string
+	// Monitored is the time the check was monitored, i.e. neither paused nor waiting for its first ping.
+	Monitored time.Duration
+	// Downtime is the time the check was down.
+	Downtime time.Duration
+	// Uptime is the percentage of the monitored time the check was up, NaN if it wasn't monitored.
+	Uptime float64
+	// Outages are the periods the check was down, clipped to the time range.
+	Outages []Outage
+	// MTTR is the mean time to recovery of all outages which ended within the time range.
+	MTTR time.Duration
+	// LongestOutage is the duration of the longest outage.
+	LongestOutage time.Duration
+	// Days contains the availability per day.
+	Days []Day
+}
+
+// Outage is a period a check was down.
+type Outage struct {
+	// Start is the start of the outage.
+	Start time.Time
+	// End is the end of the outage.
+	End time.Time
+	// Ongoing reports whether the outage lasted until the end of the time range.
+	Ongoing bool
+}
+
+// Duration returns the duration of the outage.
+func (o Outage) Duration() time.Duration {
+	return o.End.Sub(o.Start)
+}
+
+// Day is the availability of a check on a single day.
+type Day struct {
+	// Date is the start of the day.
+	Date time.Time
+	// Monitored is the time the check was monitored on this day.
+	Monitored time.Duration
+	// Uptime is the percentage of the monitored time the check was up, NaN if it wasn't monitored.
+	Uptime float64
+}
+
+// Generate computes the figures for all checks of the project in the time range from since to until.
+//
+// It requests the flips of every check via client, so keep the rate limits of the management API in mind.
+func Generate(ctx context.Context, client *healthchecks.APIClient, since, until time.Time, opts ...Option) (*Report, error) {
+	if client == nil {
+		return nil, errors.New("API client must not be nil")
+	}
+	if !since.Before(until) {
+		return nil, fmt.Errorf("since (%s) needs to be before until (%s)", since, until)
+	}
+	options := defaultOptions()
+	for _, o := range opts {
+		if err := o.apply(options); err != nil {
+			return nil, fmt.Errorf("applying option: %w", err)
+		}
+	}
+
+	checks, err := client.ListChecks(ctx, options.Tags...)
+	if err != nil {
+		return nil, fmt.Errorf("listing checks: %w", err)
+	}
+	sort.SliceStable(checks, func(i, j int) bool {
+		return checks[i].Name < checks[j].Name
+	})
+
+	r := &Report{
+		Since:  since,
+		Until:  until,
+		Checks: make([]CheckReport, 0, len(checks)),
+	}
+	for i := range checks {
+		// all flips are required, since the status at the start of the range depends on the preceding flip
+		flips, err := client.ListFlips(ctx, checks[i].ID(), time.Time{})
+		if err != nil {
+			return nil, fmt.Errorf("listing flips of check '%s': %w", checks[i].Name, err)
+		}
+		// pings are only required for telling pauses from outages, and only available for read-write keys
+		var pings []healthchecks.PingInfo
+		if checks[i].UUID != "" && hasDownFlip(flips, until) {
+			if pings, err = client.ListPings(ctx, checks[i].UUID); err != nil {
+				return nil, fmt.Errorf("listing pings of check '%s': %w", checks[i].Name, err)
+			}
+		}
+		r.Checks = append(r.Checks, compute(&checks[i], flips, pings, since, until, options.Location))
+	}
+	return r, nil
+}
+
+type state int
+
+const (
+	stateUnmonitored state = iota
+	stateUp
+	stateDown
+)
+
+// segment is a period with a constant state.
+type segment struct {
+	start, end time.Time
+	state      state
+}
+
+// hasDownFlip reports whether flips contain a flip to down before until.
+func hasDownFlip(flips []healthchecks.Flip, until time.Time) bool {
+	for _, f := range flips {
+		if !f.Up && f.Time.Before(until) {
+			return true
+		}
+	}
+	return false
+}
+
+// compute calculates the figures of check from its flips and pings, which must be ordered by time.
+func compute(check *healthchecks.CheckInfo, flips []healthchecks.Flip, pings []healthchecks.PingInfo, since, until time.Time, loc *time.Location) CheckReport {
+	segments := timeline(check, flips, pings, since, until)
+
+	r := CheckReport{
+		Name: check.Name,
+		Slug: check.Slug,
+		ID:   check.UniqueKey,
+		Tags: check.Tags,
+	}
+	r.Monitored, r.Downtime = durations(segments, since, until)
+	r.Uptime = uptime(r.Monitored, r.Downtime)
+
+	var recovered int
+	var recoveryTime time.Duration
+	for _, s := range segments {
+		if s.state != stateDown {
+			continue
+		}
+		// merge repeated flips to down
+		if n := len(r.Outages); n > 0 && r.Outages[n-1].End.Equal(s.start) {
+			r.Outages[n-1].End = s.end
+		} else {
+			r.Outages = append(r.Outages, Outage{Start: s.start, End: s.end})
+		}
+	}
+	for i := range r.Outages {
+		o := &r.Outages[i]
+		o.Ongoing = o.End.Equal(until)
+		if d := o.Duration(); d > r.LongestOutage {
+			r.LongestOutage = d
+		}
+		if !o.Ongoing {
+			recovered++
+			recoveryTime += o.Duration()
+		}
+	}
+	if recovered > 0 {
+		r.MTTR = recoveryTime / time.Duration(recovered)
+	}
+
+	for day := startOfDay(since.In(loc)); day.Before(until); day = day.AddDate(0, 0, 1) {
+		monitored, down := durations(segments, day, day.AddDate(0, 0, 1))
+		r.Days = append(r.Days, Day{
+			Date:      day,
+			Monitored: monitored,
+			Uptime:    uptime(monitored, down),
+		})
+	}
+	return r
+}
+
+// timeline splits the time range from since to until into segments according to flips and pings.
+func timeline(check *healthchecks.CheckInfo, flips []healthchecks.Flip, pings []healthchecks.PingInfo, since, until time.Time) []segment {
+	// stateAfter returns the state after the flip with index i, where prev is the state before it.
+	stateAfter := func(i int, prev state) state {
+		switch {
+		case flips[i].Up:
+			return stateUp
+		case prev != stateUp:
+			// status changes from down or paused are pausing or resuming, the check is monitored again
+			// after the next flip to up
+			return stateUnmonitored
+		case i == len(flips)-1 && check.Status == healthchecks.StatePaused:
+			return stateUnmonitored
+		case paused(check, pings, flips[i].Time):
+			return stateUnmonitored
+		default:
+			return stateDown
+		}
+	}
+
+	// replay the flips up to the start of the range
+	first := sort.Search(len(flips), func(i int) bool {
+		return flips[i].Time.After(since)
+	})
+	var current state
+	switch {
+	case len(flips) == 0:
+		current = stateFromStatus(check.Status)
+	case flips[0].Up:
+		// before the first flip to up, the check was waiting for its first ping
+		current = stateUnmonitored
+	default:
+		current = stateUp
+	}
+	for i := 0; i < first; i++ {
+		current = stateAfter(i, current)
+	}
+
+	var segments []segment
+	start := since
+	for i := first; i < len(flips) && flips[i].Time.Before(until); i++ {
+		segments = append(segments, segment{start: start, end: flips[i].Time, state: current})
+		start, current = flips[i].Time, stateAfter(i, current)
+	}
+	return append(segments, segment{start: start, end: until, state: current})
+}
+
+// paused reports whether a flip from up to down at t was caused by pausing the check, i.e. the last ping
+// before t was no failure and the check wasn't overdue yet.
+//
+// Without a ping before t, e.g. because older pings weren't kept, the flip counts as outage.
+func paused(check *healthchecks.CheckInfo, pings []healthchecks.PingInfo, t time.Time) bool {
+	for i := len(pings) - 1; i >= 0; i-- {
+		p := pings[i]
+		if !p.Time.Before(t) {
+			continue
+		}
+		switch p.Kind {
+		case "success":
+			// checks with a cron schedule have no timeout, their grace time is the lower bound
+			return t.Before(p.Time.Add(check.Timeout + check.Grace))
+		case "start":
+			return t.Before(p.Time.Add(check.Grace))
+		case "fail":
+			return false
+		}
+		// logs and ignored pings don't change the status
+	}
+	return false
+}
+
+// stateFromStatus returns the state of a check without flips.
+func stateFromStatus(s healthchecks.CheckState) state {
+	switch s {
+	case healthchecks.StateUp, healthchecks.StateGrace, healthchecks.StateStarted:
+		return stateUp
+	case healthchecks.StateDown:
+		return stateDown
+	default:
+		return stateUnmonitored
+	}
+}
+
+// durations returns the monitored time and the downtime of segments between from and to.
+func durations(segments []segment, from, to time.Time) (monitored, down time.Duration) {
+	for _, s := range segments {
+		start, end := s.start, s.end
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !start.Before(end) || s.state == stateUnmonitored {
+			continue
+		}
+		monitored += end.Sub(start)
+		if s.state == stateDown {
+			down += end.Sub(start)
+		}
+	}
+	return monitored, down
+}
+
+func uptime(monitored, down time.Duration) float64 {
+	if monitored == 0 {
+		return math.NaN()
+	}
+	return float64(monitored-down) / float64(monitored) * 100
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,310 @@
+package report
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stnokott/healthchecks"
+)
+
+var (
+	testSince = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testUntil = time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+)
+
+// at returns the time h hours after testSince.
+func at(h float64) time.Time {
+	return testSince.Add(time.Duration(h * float64(time.Hour)))
+}
+
+func up(h float64) healthchecks.Flip {
+	return healthchecks.Flip{Time: at(h), Up: true}
+}
+
+func down(h float64) healthchecks.Flip {
+	return healthchecks.Flip{Time: at(h), Up: false}
+}
+
+func ping(kind string, h float64) healthchecks.PingInfo {
+	return healthchecks.PingInfo{Kind: kind, Time: at(h)}
+}
+
+// equalPercent compares percentages, treating NaN as equal.
+func equalPercent(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestCompute(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name          string
+		status        healthchecks.CheckState
+		flips         []healthchecks.Flip
+		pings         []healthchecks.PingInfo
+		wantMonitored time.Duration
+		wantDowntime  time.Duration
+		wantUptime    float64
+		wantOutages   []Outage
+		wantMTTR      time.Duration
+		wantLongest   time.Duration
+		wantDays      []float64
+	}{
+		{
+			name:          "no flips, up",
+			status:        healthchecks.StateUp,
+			wantMonitored: 48 * time.Hour,
+			wantUptime:    100,
+			wantDays:      []float64{100, 100},
+		},
+		{
+			name:       "no flips, new",
+			status:     healthchecks.StateNew,
+			wantUptime: nan,
+			wantDays:   []float64{nan, nan},
+		},
+		{
+			name:          "outages",
+			status:        healthchecks.StateDown,
+			flips:         []healthchecks.Flip{up(-100), down(10), up(11), down(46), up(50)},
+			wantMonitored: 48 * time.Hour,
+			wantDowntime:  3 * time.Hour,
+			wantUptime:    45.0 / 48 * 100,
+			wantOutages: []Outage{
+				{Start: at(10), End: at(11)},
+				{Start: at(46), End: at(48), Ongoing: true},
+			},
+			wantMTTR:    time.Hour,
+			wantLongest: 2 * time.Hour,
+			wantDays:    []float64{23.0 / 24 * 100, 22.0 / 24 * 100},
+		},
+		{
+			name:          "outage before range",
+			status:        healthchecks.StateUp,
+			flips:         []healthchecks.Flip{down(-2), up(2)},
+			wantMonitored: 48 * time.Hour,
+			wantDowntime:  2 * time.Hour,
+			wantUptime:    46.0 / 48 * 100,
+			wantOutages:   []Outage{{Start: at(0), End: at(2)}},
+			wantMTTR:      2 * time.Hour,
+			wantLongest:   2 * time.Hour,
+			wantDays:      []float64{22.0 / 24 * 100, 100},
+		},
+		{
+			name:          "paused",
+			status:        healthchecks.StatePaused,
+			flips:         []healthchecks.Flip{up(-100), down(24)},
+			wantMonitored: 24 * time.Hour,
+			wantUptime:    100,
+			wantDays:      []float64{100, nan},
+		},
+		{
+			name:          "down again after resume",
+			status:        healthchecks.StateDown,
+			flips:         []healthchecks.Flip{up(-100), down(24)},
+			wantMonitored: 48 * time.Hour,
+			wantDowntime:  24 * time.Hour,
+			wantUptime:    50,
+			wantOutages:   []Outage{{Start: at(24), End: at(48), Ongoing: true}},
+			wantLongest:   24 * time.Hour,
+			wantDays:      []float64{100, 0},
+		},
+		{
+			name:          "created within range",
+			status:        healthchecks.StateUp,
+			flips:         []healthchecks.Flip{up(12)},
+			wantMonitored: 36 * time.Hour,
+			wantUptime:    100,
+			wantDays:      []float64{100, 100},
+		},
+		{
+			name:          "paused while down",
+			status:        healthchecks.StateUp,
+			flips:         []healthchecks.Flip{up(-1), down(1), down(2), up(3)},
+			wantMonitored: 47 * time.Hour,
+			wantDowntime:  time.Hour,
+			wantUptime:    46.0 / 47 * 100,
+			wantOutages:   []Outage{{Start: at(1), End: at(2)}},
+			wantMTTR:      time.Hour,
+			wantLongest:   time.Hour,
+			wantDays:      []float64{22.0 / 23 * 100, 100},
+		},
+		{
+			name:          "paused within range",
+			status:        healthchecks.StateUp,
+			flips:         []healthchecks.Flip{up(-100), down(10), up(20)},
+			pings:         []healthchecks.PingInfo{ping("success", 9.5), ping("log", 9.9), ping("success", 20)},
+			wantMonitored: 38 * time.Hour,
+			wantUptime:    100,
+			wantDays:      []float64{100, 100},
+		},
+		{
+			name:          "paused and resumed within range",
+			status:        healthchecks.StateUp,
+			flips:         []healthchecks.Flip{up(-100), down(10), down(12), up(20)},
+			pings:         []healthchecks.PingInfo{ping("success", 9.5), ping("success", 20)},
+			wantMonitored: 38 * time.Hour,
+			wantUptime:    100,
+			wantDays:      []float64{100, 100},
+		},
+		{
+			name:          "paused before range",
+			status:        healthchecks.StateUp,
+			flips:         []healthchecks.Flip{up(-100), down(-10), up(20)},
+			pings:         []healthchecks.PingInfo{ping("success", -10.5), ping("success", 20)},
+			wantMonitored: 28 * time.Hour,
+			wantUptime:    100,
+			wantDays:      []float64{100, 100},
+		},
+		{
+			name:          "overdue",
+			status:        healthchecks.StateUp,
+			flips:         []healthchecks.Flip{up(-100), down(10), up(20)},
+			pings:         []healthchecks.PingInfo{ping("success", 8), ping("success", 20)},
+			wantMonitored: 48 * time.Hour,
+			wantDowntime:  10 * time.Hour,
+			wantUptime:    38.0 / 48 * 100,
+			wantOutages:   []Outage{{Start: at(10), End: at(20)}},
+			wantMTTR:      10 * time.Hour,
+			wantLongest:   10 * time.Hour,
+			wantDays:      []float64{14.0 / 24 * 100, 100},
+		},
+		{
+			name:          "failed",
+			status:        healthchecks.StateUp,
+			flips:         []healthchecks.Flip{up(-100), down(10), up(20)},
+			pings:         []healthchecks.PingInfo{ping("fail", 10), ping("success", 20)},
+			wantMonitored: 48 * time.Hour,
+			wantDowntime:  10 * time.Hour,
+			wantUptime:    38.0 / 48 * 100,
+			wantOutages:   []Outage{{Start: at(10), End: at(20)}},
+			wantMTTR:      10 * time.Hour,
+			wantLongest:   10 * time.Hour,
+			wantDays:      []float64{14.0 / 24 * 100, 100},
+		},
+		{
+			name:          "started and not finished",
+			status:        healthchecks.StateUp,
+			flips:         []healthchecks.Flip{up(-100), down(11), up(20)},
+			pings:         []healthchecks.PingInfo{ping("start", 10), ping("success", 20)},
+			wantMonitored: 48 * time.Hour,
+			wantDowntime:  9 * time.Hour,
+			wantUptime:    39.0 / 48 * 100,
+			wantOutages:   []Outage{{Start: at(11), End: at(20)}},
+			wantMTTR:      9 * time.Hour,
+			wantLongest:   9 * time.Hour,
+			wantDays:      []float64{15.0 / 24 * 100, 100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := &healthchecks.CheckInfo{Name: "Backup", UUID: "id", Status: tt.status, Timeout: time.Hour, Grace: time.Hour}
+			got := compute(check, tt.flips, tt.pings, testSince, testUntil, time.UTC)
+
+			if got.Monitored != tt.wantMonitored || got.Downtime != tt.wantDowntime {
+				t.Errorf("monitored, downtime = %v, %v, want %v, %v", got.Monitored, got.Downtime, tt.wantMonitored, tt.wantDowntime)
+			}
+			if !equalPercent(got.Uptime, tt.wantUptime) {
+				t.Errorf("uptime = %v, want %v", got.Uptime, tt.wantUptime)
+			}
+			if !reflect.DeepEqual(got.Outages, tt.wantOutages) {
+				t.Errorf("outages = %+v, want %+v", got.Outages, tt.wantOutages)
+			}
+			if got.MTTR != tt.wantMTTR || got.LongestOutage != tt.wantLongest {
+				t.Errorf("MTTR, longest = %v, %v, want %v, %v", got.MTTR, got.LongestOutage, tt.wantMTTR, tt.wantLongest)
+			}
+			if len(got.Days) != len(tt.wantDays) {
+				t.Fatalf("got %d days, want %d", len(got.Days), len(tt.wantDays))
+			}
+			for i, d := range got.Days {
+				if !d.Date.Equal(at(float64(24 * i))) {
+					t.Errorf("day %d date = %v, want %v", i, d.Date, at(float64(24*i)))
+				}
+				if !equalPercent(d.Uptime, tt.wantDays[i]) {
+					t.Errorf("day %d uptime = %v, want %v", i, d.Uptime, tt.wantDays[i])
+				}
+			}
+		})
+	}
+}
+
+func TestComputeLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+6", 6*60*60)
+	check := &healthchecks.CheckInfo{Status: healthchecks.StateUp}
+	got := compute(check, nil, nil, testSince, testUntil, loc)
+
+	// days start at 18:00 UTC
+	wantDates := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, loc),
+		time.Date(2024, 1, 2, 0, 0, 0, 0, loc),
+		time.Date(2024, 1, 3, 0, 0, 0, 0, loc),
+	}
+	wantMonitored := []time.Duration{18 * time.Hour, 24 * time.Hour, 6 * time.Hour}
+	if len(got.Days) != len(wantDates) {
+		t.Fatalf("got %d days, want %d", len(got.Days), len(wantDates))
+	}
+	for i, d := range got.Days {
+		if !d.Date.Equal(wantDates[i]) || d.Monitored != wantMonitored[i] {
+			t.Errorf("day %d = %v with %v monitored, want %v with %v", i, d.Date, d.Monitored, wantDates[i], wantMonitored[i])
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/checks/":
+			if got := r.URL.Query().Get("tag"); got != "prod" {
+				t.Errorf("tag = %s, want prod", got)
+			}
+			_, _ = io.WriteString(w, `{"checks": [
+				{"name": "Web", "uuid": "web-id", "status": "up"},
+				{"name": "Backup", "uuid": "backup-id", "status": "up"}
+			]}`)
+		case "/api/v3/checks/backup-id/flips/":
+			_, _ = io.WriteString(w, `[{"timestamp": "2023-12-01T00:00:00Z", "up": 1}, {"timestamp": "2024-01-01T12:00:00Z", "up": 0},
+				{"timestamp": "2024-01-02T00:00:00Z", "up": 1}]`)
+		case "/api/v3/checks/backup-id/pings/":
+			_, _ = io.WriteString(w, `{"pings": [{"type": "fail", "date": "2024-01-01T12:00:00Z"}]}`)
+		case "/api/v3/checks/web-id/flips/":
+			_, _ = io.WriteString(w, `[]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := healthchecks.NewAPIClient("apikey", healthchecks.WithAPIURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v", err)
+	}
+	r, err := Generate(context.Background(), client, testSince, testUntil, WithTags("prod"))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(r.Checks) != 2 || r.Checks[0].Name != "Backup" || r.Checks[1].Name != "Web" {
+		t.Fatalf("checks = %+v, want Backup and Web", r.Checks)
+	}
+	if got := r.Checks[0].ID; got == "" || got == "backup-id" {
+		t.Errorf("ID of Backup = %s, want its unique key", got)
+	}
+	if got := r.Checks[0].Uptime; !equalPercent(got, 75) {
+		t.Errorf("uptime of Backup = %v, want 75", got)
+	}
+	if got := r.Checks[1].Uptime; !equalPercent(got, 100) {
+		t.Errorf("uptime of Web = %v, want 100", got)
+	}
+
+	if _, err := Generate(context.Background(), client, testUntil, testSince); err == nil || !strings.Contains(err.Error(), "needs to be before") {
+		t.Errorf("Generate() with inverted range error = %v, want error", err)
+	}
+}
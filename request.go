@@ -2,52 +2,191 @@ package healthchecks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
+	"net/url"
+	"time"
 )
 
-func request(ctx context.Context, opts *options, body io.Reader, path ...string) error {
-	fullPath := opts.RootURL.JoinPath(path...)
+func request(ctx context.Context, opts *options, b Backend, sig Signal, call sendOptions) (err error) {
+	event := PingEvent{Signal: sig, Outcome: OutcomeSkipped}
+	if len(opts.ResultHooks) > 0 {
+		start := time.Now()
+		defer func() {
+			event.Check = redactedCheck(b)
+			event.Duration = time.Since(start)
+			event.Err = err
+			if err != nil && event.Outcome == OutcomeSkipped {
+				event.Outcome = OutcomeError
+			}
+			notify(opts.ResultHooks, event)
+		}()
+	}
+
+	if opts.Disabled {
+		return nil
+	}
+
+	if opts.Limiter != nil {
+		allowed, done, limitErr := opts.Limiter.acquire(ctx, limiterKey(b), sig)
+		if limitErr != nil {
+			return fmt.Errorf("waiting for rate limit: %w", limitErr)
+		}
+		if !allowed {
+			return nil
+		}
+		defer func() {
+			done(err)
+		}()
+	}
+
+	ctx, body, cancel := deliveryContext(ctx, opts, sig, call.body)
+	defer cancel()
+
+	ping, err := newPing(opts, sig, call, body)
+	if err != nil {
+		return err
+	}
+	hb, ok := b.(*healthchecksBackend)
+	if !ok || opts.Endpoints == nil {
+		return send(ctx, opts, b, ping, call, &event)
+	}
+	order := opts.Endpoints.order()
+	if call.noRetry {
+		order = order[:1]
+	}
+	for _, i := range order {
+		b = &healthchecksBackend{root: opts.Endpoints.urls[i], path: hb.path}
+		event.StatusCode = 0
+		err = send(ctx, opts, b, ping, call, &event)
+		if !shouldFailover(&event) {
+			if reachedEndpoint(&event) {
+				opts.Endpoints.report(i, true)
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			// cancelled or timed out by the caller, which says nothing about the endpoint
+			return err
+		}
+		opts.Endpoints.report(i, false)
+	}
+	return err
+}
+
+// send sends ping using b, recording its result in event.
+func send(ctx context.Context, opts *options, b Backend, ping Ping, call sendOptions, event *PingEvent) error {
+	timeout := opts.Timeout
+	if call.timeout > 0 {
+		timeout = call.timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 
-	req, err := newRequest(ctx, fullPath.String(), body)
-	// required for reliable sequential requests
-	req.Close = true
+	req, err := b.NewRequest(ctx, ping)
+	if errors.Is(err, ErrUnsupportedSignal) {
+		return nil
+	}
 	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
+		return fmt.Errorf("creating request: %w", redactError(err, redactURL(b, ping, nil)))
+	}
+
+	redactedURL := redactURL(b, ping, req.URL)
+	if opts.DryRun != nil {
+		return dryRun(opts.DryRun, ping.Signal, req.Method, redactedURL, ping.Body)
 	}
+	req.Close = opts.DisableKeepAlive
 
-	resp, err := opts.HTTPClient.Do(req)
+	record, err := opts.Breaker.acquire()
 	if err != nil {
+		event.Outcome = OutcomeCircuitOpen
 		return fmt.Errorf("requesting: %w", err)
 	}
+	resp, attempts, err := do(opts.HTTPClient, req, !call.noRetry)
+	record(ctx, err)
+	event.Attempt += attempts
+	if err != nil {
+		event.Outcome = OutcomeTransportError
+		return fmt.Errorf("requesting: %w", redactError(err, redactedURL))
+	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 	// body is required to differentiate between 200s (OK, not found, rate limited etc.).
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err := readResponse(resp)
 	if err != nil {
 		respBody = []byte("no information")
 	}
-	respStr := string(respBody)
-	// if not 200, we return an error with the response body
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("HTTP response status %d: %s", resp.StatusCode, respStr)
+	event.StatusCode = resp.StatusCode
+	err = b.CheckResponse(resp, respBody)
+	event.Outcome = responseOutcome(resp.StatusCode, respBody, err)
+	return err
+}
+
+// newPing merges the options of the check and the call into the [Ping] passed to the backend.
+func newPing(opts *options, sig Signal, call sendOptions, body io.Reader) (Ping, error) {
+	p := Ping{
+		Signal: sig,
+		RunID:  opts.RunID,
+		Create: opts.Create || call.create,
+	}
+	if call.runID != "" {
+		p.RunID = call.runID
 	}
-	// if the body is "OK", it's an actual good response
-	if respStr != "OK" {
-		return fmt.Errorf("HTTP response not OK: '%s'", respStr)
+	if body != nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return Ping{}, fmt.Errorf("reading body: %w", err)
+		}
+		p.Body = b
 	}
-	return nil
+	return p, nil
 }
 
-func newRequest(ctx context.Context, path string, body io.Reader) (*http.Request, error) {
-	var method string
-	if body == nil {
-		method = "GET"
-	} else {
-		method = "POST"
+// limiterKey identifies the check of b for rate limiting.
+//
+// Other backends are only used by a single [Check], which has its own limiter.
+func limiterKey(b Backend) string {
+	if hb, ok := b.(*healthchecksBackend); ok {
+		return hb.checkURL().Path
 	}
+	return ""
+}
+
+// redactURL returns u, the URL of a request created by b for p, with secrets redacted.
+//
+// Only healthchecks.io URLs are known to keep secrets in the first path segment,
+// so the whole path is redacted for other backends. u may be nil if the request couldn't be created.
+func redactURL(b Backend, p Ping, u *url.URL) *url.URL {
+	if hb, ok := b.(*healthchecksBackend); ok {
+		return hb.redacted().pingURL(p)
+	}
+	if u == nil {
+		return &url.URL{Path: redacted}
+	}
+	return redactAllPath(u)
+}
+
+// redactAllPath returns a copy of u with the whole path redacted.
+func redactAllPath(u *url.URL) *url.URL {
+	r := *u
+	r.Path, r.RawPath = "/"+redacted, "/"+redacted
+	r.User = nil
+	return &r
+}
 
-	return http.NewRequestWithContext(ctx, method, path, body)
+// redactError replaces the URL of err with u, if err is a [*url.Error] as returned by [http.Client.Do].
+//
+// The URL of a check contains its secrets, which would otherwise end up in logs.
+// The returned error still wraps the cause, e.g. [context.DeadlineExceeded].
+func redactError(err error, u *url.URL) error {
+	urlErr, ok := err.(*url.Error) // not errors.As, wrapping errors would still contain the URL in their message
+	if !ok {
+		return err
+	}
+	return &url.Error{Op: urlErr.Op, URL: u.String(), Err: urlErr.Err}
 }
@@ -26,14 +26,14 @@ func TestRequest(t *testing.T) {
 	}
 	tests := []struct {
 		name             string
-		operations       []string
+		signals          []Signal
 		serverPathPrefix string
 		args             args
 		wantErr          bool
 	}{
 		{
 			name:             "uuid valid",
-			operations:       []string{"", "/start", "/fail"},
+			signals:          []Signal{SignalSuccess, SignalStart, SignalFail},
 			serverPathPrefix: "",
 			args: args{
 				opts: &options{
@@ -47,7 +47,7 @@ func TestRequest(t *testing.T) {
 		},
 		{
 			name:             "uuid invalid",
-			operations:       []string{""},
+			signals:          []Signal{SignalSuccess},
 			serverPathPrefix: "",
 			args: args{
 				opts: &options{
@@ -61,7 +61,7 @@ func TestRequest(t *testing.T) {
 		},
 		{
 			name:             "ping key valid, slug valid",
-			operations:       []string{"", "/start", "/fail"},
+			signals:          []Signal{SignalSuccess, SignalStart, SignalFail},
 			serverPathPrefix: "",
 			args: args{
 				opts: &options{
@@ -75,7 +75,7 @@ func TestRequest(t *testing.T) {
 		},
 		{
 			name:             "ping key valid, slug invalid",
-			operations:       []string{""},
+			signals:          []Signal{SignalSuccess},
 			serverPathPrefix: "",
 			args: args{
 				opts: &options{
@@ -89,7 +89,7 @@ func TestRequest(t *testing.T) {
 		},
 		{
 			name:             "ping key invalid",
-			operations:       []string{""},
+			signals:          []Signal{SignalSuccess},
 			serverPathPrefix: "",
 			args: args{
 				opts: &options{
@@ -103,7 +103,7 @@ func TestRequest(t *testing.T) {
 		},
 		{
 			name:             "path invalid",
-			operations:       []string{""},
+			signals:          []Signal{SignalSuccess},
 			serverPathPrefix: "",
 			args: args{
 				opts: &options{
@@ -117,7 +117,7 @@ func TestRequest(t *testing.T) {
 		},
 		{
 			name:             "operation invalid",
-			operations:       []string{"/bar"},
+			signals:          []Signal{SignalSuccess},
 			serverPathPrefix: "",
 			args: args{
 				opts: &options{
@@ -125,13 +125,13 @@ func TestRequest(t *testing.T) {
 					HTTPClient: http.DefaultClient,
 				},
 				body: nil,
-				path: []string{"/", config.UUID},
+				path: []string{"/", config.UUID, "/bar"},
 			},
 			wantErr: true,
 		},
 		{
 			name:             "with body",
-			operations:       []string{"/log"},
+			signals:          []Signal{SignalLog},
 			serverPathPrefix: "",
 			args: args{
 				opts: &options{
@@ -146,13 +146,9 @@ func TestRequest(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			for _, op := range tt.operations {
-				t.Run(op, func(t *testing.T) {
-					path := make([]string, len(tt.args.path)+1)
-					copy(path, tt.args.path)
-					path[len(path)-1] = op
-
-					if err := request(context.Background(), tt.args.opts, tt.args.body, path...); (err != nil) != tt.wantErr {
+			for _, sig := range tt.signals {
+				t.Run(strings.Join(sig.suffix(), ""), func(t *testing.T) {
+					if err := request(context.Background(), tt.args.opts, &healthchecksBackend{root: tt.args.opts.RootURL, path: strings.Join(tt.args.path, "")}, sig, sendOptions{body: tt.args.body}); (err != nil) != tt.wantErr {
 						t.Errorf("request() error = %v, wantErr %v", err, tt.wantErr)
 					}
 				})
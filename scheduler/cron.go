@@ -0,0 +1,368 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule determines when a job runs.
+type Schedule interface {
+	// Next returns the first activation time after t, or the zero time if there is none.
+	Next(t time.Time) time.Time
+}
+
+// compile-time interface implementation checks
+var (
+	_ Schedule = (*Cron)(nil)
+	_ Schedule = interval(0)
+)
+
+// Cron is a [Schedule] defined by a cron expression.
+//
+// Use [ParseCron] for obtaining a new instance.
+type Cron struct {
+	spec string
+	loc  *time.Location
+
+	minute bits
+	hour   bits
+	dom    bits
+	month  bits
+	dow    bits
+
+	domStar bool // day-of-month field starts with "*"
+	dowStar bool // day-of-week field starts with "*"
+	lastDOM bool // day-of-month contains "L"
+
+	nthDOW  []nthWeekday // day-of-week contains "<weekday>#<n>"
+	lastDOW bits         // day-of-week contains "<weekday>L"
+}
+
+// nthWeekday is the n-th occurrence of a weekday in a month.
+type nthWeekday struct {
+	weekday time.Weekday
+	n       int
+}
+
+var cronAliases = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dowNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+// ParseCron parses a cron expression, which is evaluated in the time zone loc (UTC if nil).
+//
+// The syntax matches the one used by healthchecks.io for checks of the "Cron" kind,
+// so the same expression and time zone can be configured for the check and the job:
+//
+//   - five fields: minute (0-59), hour (0-23), day of month (1-31), month (1-12 or JAN-DEC), day of week (0-7 or SUN-SAT, 0 and 7 are Sunday)
+//   - "*" for all values, lists ("1,15"), ranges ("1-5"), steps ("*/15", "0-30/10", "5/10")
+//   - "L" for the last day of the month in the day-of-month field
+//   - "<weekday>#<n>" for the n-th and "<weekday>L" for the last given weekday of the month in the day-of-week field
+//   - the aliases @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly
+//
+// Like in most cron implementations, a day matches if either the day-of-month or the day-of-week field matches,
+// unless one of them starts with "*", in which case both need to match.
+func ParseCron(spec string, loc *time.Location) (*Cron, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	expr := strings.TrimSpace(spec)
+	if alias, ok := cronAliases[strings.ToLower(expr)]; ok {
+		expr = alias
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression '%s' has %d fields, expected 5", spec, len(fields))
+	}
+
+	c := &Cron{
+		spec:    spec,
+		loc:     loc,
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	if c.minute, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if c.hour, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if err = c.parseDOM(fields[2]); err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field: %w", err)
+	}
+	if c.month, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	if err = c.parseDOW(fields[4]); err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field: %w", err)
+	}
+	return c, nil
+}
+
+// String returns the cron expression as passed to [ParseCron].
+func (c *Cron) String() string {
+	return c.spec
+}
+
+// Location returns the time zone the expression is evaluated in.
+func (c *Cron) Location() *time.Location {
+	return c.loc
+}
+
+func (c *Cron) parseDOM(field string) error {
+	var rest []string
+	for _, part := range strings.Split(field, ",") {
+		if strings.EqualFold(part, "L") {
+			c.lastDOM = true
+			continue
+		}
+		rest = append(rest, part)
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+	var err error
+	c.dom, err = parseField(strings.Join(rest, ","), 1, 31, nil)
+	return err
+}
+
+func (c *Cron) parseDOW(field string) error {
+	var rest []string
+	for _, part := range strings.Split(field, ",") {
+		if weekdayStr, nStr, ok := strings.Cut(part, "#"); ok {
+			weekday, err := parseValue(weekdayStr, 0, 7, dowNames)
+			if err != nil {
+				return err
+			}
+			n, err := strconv.Atoi(nStr)
+			if err != nil || n < 1 || n > 5 {
+				return fmt.Errorf("invalid occurrence '%s', expected 1-5", nStr)
+			}
+			c.nthDOW = append(c.nthDOW, nthWeekday{weekday: time.Weekday(weekday % 7), n: n})
+			continue
+		}
+		if weekdayStr, ok := strings.CutSuffix(strings.ToLower(part), "l"); ok && weekdayStr != "" {
+			weekday, err := parseValue(weekdayStr, 0, 7, dowNames)
+			if err != nil {
+				return err
+			}
+			c.lastDOW = c.lastDOW.set(weekday % 7)
+			continue
+		}
+		rest = append(rest, part)
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+	dow, err := parseField(strings.Join(rest, ","), 0, 7, dowNames)
+	if err != nil {
+		return err
+	}
+	// 7 is Sunday as well
+	if dow.has(7) {
+		dow = dow.set(0)
+	}
+	c.dow = dow
+	return nil
+}
+
+// bits is a set of small non-negative integers.
+type bits uint64
+
+func (b bits) has(n int) bool {
+	return b&(1<<uint(n)) != 0
+}
+
+func (b bits) set(n int) bits {
+	return b | 1<<uint(n)
+}
+
+// parseField parses a comma-separated list of values, ranges and steps within [min, max].
+func parseField(field string, min, max int, names map[string]int) (bits, error) {
+	var b bits
+	for _, part := range strings.Split(field, ",") {
+		rangeStr, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step '%s'", stepStr)
+			}
+		}
+
+		var lo, hi int
+		switch {
+		case rangeStr == "*":
+			lo, hi = min, max
+		case strings.Contains(rangeStr, "-"):
+			loStr, hiStr, _ := strings.Cut(rangeStr, "-")
+			var err error
+			if lo, err = parseValue(loStr, min, max, names); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(hiStr, min, max, names); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range '%s'", rangeStr)
+			}
+		default:
+			var err error
+			if lo, err = parseValue(rangeStr, min, max, names); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if hasStep {
+				// "5/10" is short for "5-<max>/10"
+				hi = max
+			}
+		}
+
+		for n := lo; n <= hi; n += step {
+			b = b.set(n)
+		}
+	}
+	return b, nil
+}
+
+func parseValue(s string, min, max int, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToLower(s)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s'", s)
+	}
+	if n < min || n > max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, min, max)
+	}
+	return n, nil
+}
+
+// errNoActivation is used internally if a cron expression never matches, e.g. "0 0 30 2 *".
+var errNoActivation = errors.New("no activation")
+
+// Next implements [Schedule].
+//
+// It returns the zero time if the expression doesn't match any time within the next five years.
+func (c *Cron) Next(t time.Time) time.Time {
+	next, err := c.next(t)
+	if err != nil {
+		return time.Time{}
+	}
+	return next.In(t.Location())
+}
+
+func (c *Cron) next(t time.Time) (time.Time, error) {
+	t = t.In(c.loc)
+	// start at the next full minute
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, c.loc)
+	yearLimit := t.Year() + 5
+
+wrap:
+	for t.Year() <= yearLimit {
+		for !c.month.has(int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.loc)
+			if t.Month() == time.January {
+				continue wrap
+			}
+		}
+		for !c.dayMatches(t) {
+			month := t.Month()
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.loc)
+			if t.Month() != month {
+				continue wrap
+			}
+		}
+		for !c.hour.has(t.Hour()) {
+			day := t.Day()
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.loc)
+			if t.Day() != day {
+				continue wrap
+			}
+		}
+		for !c.minute.has(t.Minute()) {
+			hour := t.Hour()
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, c.loc)
+			if t.Hour() != hour {
+				continue wrap
+			}
+		}
+		return t, nil
+	}
+	return time.Time{}, errNoActivation
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	dom := c.domMatches(t)
+	dow := c.dowMatches(t)
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func (c *Cron) domMatches(t time.Time) bool {
+	if c.dom.has(t.Day()) {
+		return true
+	}
+	return c.lastDOM && t.Day() == daysIn(t)
+}
+
+func (c *Cron) dowMatches(t time.Time) bool {
+	weekday := t.Weekday()
+	if c.dow.has(int(weekday)) {
+		return true
+	}
+	if c.lastDOW.has(int(weekday)) && t.Day()+7 > daysIn(t) {
+		return true
+	}
+	for _, nth := range c.nthDOW {
+		if nth.weekday == weekday && (t.Day()-1)/7+1 == nth.n {
+			return true
+		}
+	}
+	return false
+}
+
+// daysIn returns the number of days in the month of t.
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// interval is a [Schedule] activating in a fixed interval.
+type interval time.Duration
+
+// Every returns a [Schedule] which activates every d, starting d after the scheduler was started.
+func Every(d time.Duration) Schedule {
+	return interval(d)
+}
+
+// Next implements [Schedule].
+func (i interval) Next(t time.Time) time.Time {
+	if i <= 0 {
+		return time.Time{}
+	}
+	return t.Add(time.Duration(i))
+}
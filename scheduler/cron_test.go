@@ -0,0 +1,226 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "every minute",
+			spec: "* * * * *",
+			from: time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC),
+			want: time.Date(2024, 1, 1, 12, 1, 0, 0, time.UTC),
+		},
+		{
+			name: "strictly after",
+			spec: "* * * * *",
+			from: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 1, 12, 1, 0, 0, time.UTC),
+		},
+		{
+			name: "step",
+			spec: "*/15 * * * *",
+			from: time.Date(2024, 1, 1, 12, 16, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "step from value",
+			spec: "5/20 * * * *",
+			from: time.Date(2024, 1, 1, 12, 26, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 1, 12, 45, 0, 0, time.UTC),
+		},
+		{
+			name: "list and range",
+			spec: "0 8-10,14 * * *",
+			from: time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "next day",
+			spec: "30 2 * * *",
+			from: time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 2, 2, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "month name",
+			spec: "0 0 1 mar *",
+			from: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weekday names",
+			spec: "0 9 * * MON-FRI",
+			from: time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC), // Friday
+			want: time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "sunday as 7",
+			spec: "0 0 * * 7",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), // Monday
+			want: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month or day of week",
+			spec: "0 0 15 * FRI",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month and wildcard day of week",
+			spec: "0 0 15 * *",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month and stepped day of week",
+			spec: "0 0 1-7 * */7",
+			from: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), // first Sunday
+		},
+		{
+			name: "last day of month",
+			spec: "0 0 L * *",
+			from: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "nth weekday",
+			spec: "0 0 * * 2#3",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "last weekday",
+			spec: "0 0 * * FRIL",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "leap day",
+			spec: "0 0 29 2 *",
+			from: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "alias",
+			spec: "@weekly",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "never",
+			spec: "0 0 30 2 *",
+			from: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCron(tt.spec, nil)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			if got := c.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronNextLocation(t *testing.T) {
+	berlin := mustLocation(t, "Europe/Berlin")
+
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "evaluated in location",
+			spec: "0 9 * * *",
+			from: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), // 10:00 in Berlin
+			want: time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "skipped hour at start of DST",
+			spec: "30 2 * * *",
+			from: time.Date(2024, 3, 31, 0, 0, 0, 0, berlin),
+			want: time.Date(2024, 4, 1, 2, 30, 0, 0, berlin),
+		},
+		{
+			name: "repeated hour at end of DST runs once",
+			spec: "30 2 * * *",
+			from: time.Date(2024, 10, 27, 2, 30, 0, 0, berlin),
+			want: time.Date(2024, 10, 28, 2, 30, 0, 0, berlin),
+		},
+		{
+			name: "hourly across end of DST",
+			spec: "0 * * * *",
+			from: time.Date(2024, 10, 27, 1, 30, 0, 0, berlin),
+			want: time.Date(2024, 10, 27, 2, 0, 0, 0, berlin),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCron(tt.spec, berlin)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			if got := c.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * foo *",
+		"* * * * MON#6",
+		"* * * * FOOL",
+	}
+	for _, spec := range tests {
+		t.Run(spec, func(t *testing.T) {
+			if _, err := ParseCron(spec, nil); err == nil {
+				t.Errorf("ParseCron(%q) error = nil, want error", spec)
+			}
+		})
+	}
+}
+
+func TestEvery(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got, want := Every(time.Hour).Next(from), from.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+	if got := Every(0).Next(from); !got.IsZero() {
+		t.Errorf("Next() = %v, want zero time", got)
+	}
+}
@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type options struct {
+	Logger *slog.Logger
+}
+
+func defaultOptions() *options {
+	return &options{
+		Logger: slog.Default(),
+	}
+}
+
+// Option applies a configuration option to a [Scheduler].
+type Option interface {
+	apply(opts *options) error
+}
+
+type loggerOption struct {
+	logger *slog.Logger
+}
+
+var _ Option = loggerOption{}
+
+func (l loggerOption) apply(opts *options) error {
+	if l.logger == nil {
+		return errors.New("logger must not be nil")
+	}
+	opts.Logger = l.logger
+	return nil
+}
+
+// WithLogger sets the logger for skipped runs, failed jobs and signals which could not be sent.
+//
+// Defaults to [slog.Default].
+func WithLogger(l *slog.Logger) Option {
+	return loggerOption{logger: l}
+}
+
+type jobOptions struct {
+	jitter       time.Duration
+	allowOverlap bool
+	timeout      time.Duration
+}
+
+// JobOption applies a configuration option to a single job added via [Scheduler.Add].
+type JobOption interface {
+	applyJob(opts *jobOptions) error
+}
+
+type jitterOption time.Duration
+
+var _ JobOption = jitterOption(0)
+
+func (j jitterOption) applyJob(opts *jobOptions) error {
+	if j <= 0 {
+		return fmt.Errorf("jitter is %d, needs to be > 0", j)
+	}
+	opts.jitter = time.Duration(j)
+	return nil
+}
+
+// WithJitter delays each run by a random duration in [0, max).
+//
+// This spreads the load if many instances run the same job.
+// Keep it well below the grace time of the check.
+func WithJitter(max time.Duration) JobOption {
+	return jitterOption(max)
+}
+
+type overlapOption struct{}
+
+var _ JobOption = overlapOption{}
+
+func (overlapOption) applyJob(opts *jobOptions) error {
+	opts.allowOverlap = true
+	return nil
+}
+
+// WithOverlap allows a run to start while the previous one is still in progress.
+//
+// By default, such runs are skipped and reported to the check via the "log" signal.
+func WithOverlap() JobOption {
+	return overlapOption{}
+}
+
+type jobTimeoutOption time.Duration
+
+var _ JobOption = jobTimeoutOption(0)
+
+func (t jobTimeoutOption) applyJob(opts *jobOptions) error {
+	if t <= 0 {
+		return fmt.Errorf("timeout is %d, needs to be > 0", t)
+	}
+	opts.timeout = time.Duration(t)
+	return nil
+}
+
+// WithJobTimeout cancels the context passed to the job after t.
+func WithJobTimeout(t time.Duration) JobOption {
+	return jobTimeoutOption(t)
+}
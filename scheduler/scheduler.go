@@ -0,0 +1,349 @@
+// Package scheduler runs periodic jobs in-process and reports each run to a healthchecks.io check.
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	mathrand "math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stnokott/healthchecks"
+)
+
+var (
+	// ErrStopped is returned when adding a job to a scheduler which has been shut down.
+	ErrStopped = errors.New("scheduler stopped")
+	// ErrDuplicateJob is returned when adding a job with a name which is already in use.
+	ErrDuplicateJob = errors.New("duplicate job name")
+)
+
+// errShutdown is the cause of the context passed to jobs which are still running when [Scheduler.Shutdown] gives up.
+var errShutdown = errors.New("scheduler shut down")
+
+// Func is the function executed by a job.
+//
+// A non-nil error (or a panic) sends the "fail" signal to the job's check, with the error message attached.
+type Func func(ctx context.Context) error
+
+// Scheduler runs jobs according to their [Schedule].
+//
+// Each run sends the "start" signal to the job's check, followed by either "success" or "fail".
+// All signals of a run share a run ID if the check supports it (like [healthchecks.Check]),
+// so healthchecks.io can measure the run's duration even if runs overlap.
+//
+// Use [New] for obtaining a new instance.
+type Scheduler struct {
+	opts *options
+
+	ctx    context.Context // passed to jobs, cancelled when Shutdown gives up waiting
+	cancel context.CancelCauseFunc
+	stop   chan struct{} // closed by Shutdown
+
+	mu      sync.Mutex
+	jobs    map[string]*Job
+	started bool
+	stopped bool
+
+	loops sync.WaitGroup // one per job
+	runs  sync.WaitGroup // one per run in progress
+}
+
+// New creates a new instance of [Scheduler].
+//
+// Jobs can be added before and after calling [Scheduler.Start].
+func New(opts ...Option) (*Scheduler, error) {
+	options := defaultOptions()
+	for _, o := range opts {
+		if err := o.apply(options); err != nil {
+			return nil, fmt.Errorf("applying option: %w", err)
+		}
+	}
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &Scheduler{
+		opts:   options,
+		ctx:    ctx,
+		cancel: cancel,
+		stop:   make(chan struct{}),
+		jobs:   map[string]*Job{},
+	}, nil
+}
+
+// Add registers a new job called name, which runs fn according to schedule and reports to n.
+//
+// Use [ParseCron] with the check's cron expression and time zone to make sure healthchecks.io expects
+// the signals at the same times the job runs. For checks of the "Simple" kind, use [Every] with the check's period.
+func (s *Scheduler) Add(name string, schedule Schedule, n healthchecks.Notifier, fn Func, opts ...JobOption) (*Job, error) {
+	if name == "" {
+		return nil, errors.New("job name must not be empty")
+	}
+	if schedule == nil {
+		return nil, errors.New("schedule must not be nil")
+	}
+	if n == nil {
+		return nil, errors.New("notifier must not be nil")
+	}
+	if fn == nil {
+		return nil, errors.New("job function must not be nil")
+	}
+	var jobOpts jobOptions
+	for _, o := range opts {
+		if err := o.applyJob(&jobOpts); err != nil {
+			return nil, fmt.Errorf("applying job option: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return nil, ErrStopped
+	}
+	if _, ok := s.jobs[name]; ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrDuplicateJob, name)
+	}
+	j := &Job{
+		name:     name,
+		schedule: schedule,
+		notifier: n,
+		fn:       fn,
+		opts:     jobOpts,
+	}
+	s.jobs[name] = j
+	if s.started {
+		s.startLoop(j)
+	}
+	return j, nil
+}
+
+// Jobs returns all registered jobs, sorted by name.
+func (s *Scheduler) Jobs() []*Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := make([]*Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j)
+	}
+	sort.Slice(jobs, func(a, b int) bool {
+		return jobs[a].name < jobs[b].name
+	})
+	return jobs
+}
+
+// Start starts scheduling the registered jobs. It returns immediately.
+//
+// Calling Start more than once or after [Scheduler.Shutdown] has no effect.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started || s.stopped {
+		return
+	}
+	s.started = true
+	for _, j := range s.jobs {
+		s.startLoop(j)
+	}
+}
+
+// Shutdown stops scheduling new runs and waits for runs in progress to finish, including their signals.
+//
+// If ctx ends before that, the context passed to the remaining runs is cancelled and ctx.Err() is returned
+// without waiting any further. Their signals are sent nonetheless.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stop)
+	}
+	s.mu.Unlock()
+
+	s.loops.Wait()
+	done := make(chan struct{})
+	go func() {
+		s.runs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.cancel(errShutdown)
+		return nil
+	case <-ctx.Done():
+		s.cancel(errShutdown)
+		return ctx.Err()
+	}
+}
+
+// startLoop starts scheduling j.
+//
+// The caller must hold s.mu.
+func (s *Scheduler) startLoop(j *Job) {
+	s.loops.Add(1)
+	go func() {
+		defer s.loops.Done()
+		s.loop(j)
+	}()
+}
+
+func (s *Scheduler) loop(j *Job) {
+	defer j.setNext(time.Time{})
+
+	planned := time.Now()
+	for {
+		now := time.Now()
+		next := j.schedule.Next(planned)
+		if !next.IsZero() && next.Before(now) {
+			// don't catch up on missed activations, e.g. after the system was suspended
+			next = j.schedule.Next(now)
+		}
+		if next.IsZero() {
+			s.opts.Logger.Warn("job has no further activations", "job", j.name)
+			return
+		}
+		planned = next
+		at := next.Add(j.jitter())
+		j.setNext(at)
+
+		timer := time.NewTimer(time.Until(at))
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		s.trigger(j)
+	}
+}
+
+// trigger starts a run of j unless the previous one is still in progress and overlapping runs aren't allowed.
+func (s *Scheduler) trigger(j *Job) {
+	if j.running.Add(1) > 1 && !j.opts.allowOverlap {
+		j.running.Add(-1)
+		s.opts.Logger.Warn("skipping job run, previous run still in progress", "job", j.name)
+		msg := "skipped run: previous run still in progress"
+		if err := j.notifier.Log(context.WithoutCancel(s.ctx), msg); err != nil {
+			s.opts.Logger.Error("sending signal", "job", j.name, "signal", healthchecks.SignalLog, "error", err)
+		}
+		return
+	}
+
+	s.runs.Add(1)
+	go func() {
+		defer s.runs.Done()
+		defer j.running.Add(-1)
+		s.run(j)
+	}()
+}
+
+func (s *Scheduler) run(j *Job) {
+	ctx := s.ctx
+	if j.opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.opts.timeout)
+		defer cancel()
+	}
+	// signals are sent even if the run has been cancelled
+	pingCtx := context.WithoutCancel(ctx)
+	rid := newRunID()
+
+	s.opts.Logger.Debug("running job", "job", j.name)
+	s.signal(pingCtx, j, healthchecks.SignalStart, "", rid)
+	if err := call(ctx, j.fn); err != nil {
+		s.opts.Logger.Warn("job failed", "job", j.name, "error", err)
+		s.signal(pingCtx, j, healthchecks.SignalFail, err.Error(), rid)
+		return
+	}
+	s.signal(pingCtx, j, healthchecks.SignalSuccess, "", rid)
+}
+
+// call calls fn, turning a panic into an error.
+func call(ctx context.Context, fn Func) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
+
+// signal sends sig with the optional body to the check of j.
+//
+// If the check supports it, rid is attached as run ID.
+// Errors are logged, since they must not affect the job.
+func (s *Scheduler) signal(ctx context.Context, j *Job, sig healthchecks.Signal, body string, rid string) {
+	var err error
+	snd, ok := j.notifier.(healthchecks.Sender)
+	switch {
+	case sig == healthchecks.SignalFail:
+		err = healthchecks.FailWithBody(ctx, j.notifier, body, healthchecks.WithCallRunID(rid))
+	case ok:
+		err = snd.Send(ctx, sig, healthchecks.WithCallRunID(rid))
+	case sig == healthchecks.SignalStart:
+		err = j.notifier.Start(ctx)
+	default:
+		err = j.notifier.Success(ctx)
+	}
+	if err != nil {
+		s.opts.Logger.Error("sending signal", "job", j.name, "signal", sig, "error", err)
+	}
+}
+
+// newRunID returns a random UUID (version 4) for correlating the signals of a run.
+func newRunID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// Job is a job registered via [Scheduler.Add].
+type Job struct {
+	name     string
+	schedule Schedule
+	notifier healthchecks.Notifier
+	fn       Func
+	opts     jobOptions
+
+	running atomic.Int32 // number of runs in progress
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// Name returns the name the job has been registered with.
+func (j *Job) Name() string {
+	return j.name
+}
+
+// Next returns the time of the job's next run, including jitter.
+//
+// It returns the zero time if the scheduler hasn't been started yet, has been shut down
+// or the job's schedule has no further activations.
+func (j *Job) Next() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.next
+}
+
+// Running reports whether a run of the job is currently in progress.
+func (j *Job) Running() bool {
+	return j.running.Load() > 0
+}
+
+func (j *Job) setNext(t time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.next = t
+}
+
+// jitter returns a random delay for the next run.
+func (j *Job) jitter() time.Duration {
+	if j.opts.jitter <= 0 {
+		return 0
+	}
+	return time.Duration(mathrand.Int63n(int64(j.opts.jitter)))
+}
@@ -0,0 +1,296 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stnokott/healthchecks"
+)
+
+// recordingNotifier records all signals in the order they are received.
+type recordingNotifier struct {
+	mu      sync.Mutex
+	signals []string
+}
+
+func (r *recordingNotifier) record(s string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.signals = append(r.signals, s)
+	return nil
+}
+
+func (r *recordingNotifier) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.signals...)
+}
+
+func (r *recordingNotifier) Start(context.Context) error   { return r.record("start") }
+func (r *recordingNotifier) Success(context.Context) error { return r.record("success") }
+func (r *recordingNotifier) Fail(context.Context) error    { return r.record("fail") }
+func (r *recordingNotifier) Log(_ context.Context, msg string) error {
+	return r.record("log:" + msg)
+}
+func (r *recordingNotifier) ExitStatus(context.Context, int) error { return r.record("exit-status") }
+
+var _ healthchecks.Notifier = (*recordingNotifier)(nil)
+
+// sendingNotifier additionally implements Send, validating the options passed to it.
+type sendingNotifier struct {
+	recordingNotifier
+}
+
+func (s *sendingNotifier) Send(ctx context.Context, sig healthchecks.Signal, opts ...healthchecks.SendOption) error {
+	check, err := healthchecks.NewUUID("6da9bc25-880d-4a73-a0e5-e833405e206f", healthchecks.WithDisabled())
+	if err != nil {
+		return err
+	}
+	if err := check.Send(ctx, sig, opts...); err != nil {
+		return err
+	}
+	return s.record(sig.String())
+}
+
+func newTestScheduler(t *testing.T) *Scheduler {
+	t.Helper()
+	s, err := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Shutdown(context.Background())
+	})
+	return s
+}
+
+// waitFor polls cond until it returns true or the test times out.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSchedulerRun(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   Func
+		want []string
+	}{
+		{
+			name: "success",
+			fn:   func(context.Context) error { return nil },
+			want: []string{"start", "success"},
+		},
+		{
+			name: "error",
+			fn:   func(context.Context) error { return errors.New("disk full") },
+			want: []string{"start", "log:disk full", "fail"},
+		},
+		{
+			name: "panic",
+			fn:   func(context.Context) error { panic("oops") },
+			want: []string{"start", "log:panic: oops", "fail"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestScheduler(t)
+			n := &recordingNotifier{}
+			done := make(chan struct{})
+			var once sync.Once
+			if _, err := s.Add("job", Every(10*time.Millisecond), n, func(ctx context.Context) error {
+				defer once.Do(func() { close(done) })
+				return tt.fn(ctx)
+			}); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+			s.Start()
+			<-done
+			if err := s.Shutdown(context.Background()); err != nil {
+				t.Fatalf("Shutdown() error = %v", err)
+			}
+			if got := n.recorded()[:len(tt.want)]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("signals = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedulerRunID(t *testing.T) {
+	s := newTestScheduler(t)
+	n := &sendingNotifier{}
+	done := make(chan struct{})
+	var once sync.Once
+	if _, err := s.Add("job", Every(10*time.Millisecond), n, func(context.Context) error {
+		once.Do(func() { close(done) })
+		return nil
+	}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	s.Start()
+	<-done
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	want := []string{"start", "success"}
+	if got := n.recorded()[:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("signals = %v, want %v", got, want)
+	}
+}
+
+func TestNewRunID(t *testing.T) {
+	a, b := newRunID(), newRunID()
+	if a == b {
+		t.Errorf("newRunID() returned %s twice", a)
+	}
+	if _, err := healthchecks.NewUUID(a); err != nil {
+		t.Errorf("newRunID() = %s, not a valid UUID: %v", a, err)
+	}
+}
+
+func TestSchedulerOverlap(t *testing.T) {
+	s := newTestScheduler(t)
+	n := &recordingNotifier{}
+	release := make(chan struct{})
+	job, err := s.Add("job", Every(10*time.Millisecond), n, func(context.Context) error {
+		<-release
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	s.Start()
+
+	skipped := "log:skipped run: previous run still in progress"
+	waitFor(t, func() bool {
+		for _, sig := range n.recorded() {
+			if sig == skipped {
+				return true
+			}
+		}
+		return false
+	})
+	if !job.Running() {
+		t.Error("Running() = false, want true")
+	}
+	close(release)
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	starts := 0
+	for _, sig := range n.recorded() {
+		if sig == "start" {
+			starts++
+		}
+	}
+	if starts != 1 {
+		t.Errorf("got %d runs, want 1", starts)
+	}
+}
+
+func TestSchedulerShutdown(t *testing.T) {
+	s := newTestScheduler(t)
+	n := &recordingNotifier{}
+	started := make(chan struct{})
+	var once sync.Once
+	if _, err := s.Add("job", Every(10*time.Millisecond), n, func(ctx context.Context) error {
+		once.Do(func() { close(started) })
+		<-ctx.Done()
+		return ctx.Err()
+	}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	s.Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	// the cancelled run still reports its failure
+	waitFor(t, func() bool {
+		got := n.recorded()
+		return len(got) > 0 && got[len(got)-1] == "fail"
+	})
+
+	if _, err := s.Add("other", Every(time.Second), n, func(context.Context) error { return nil }); !errors.Is(err, ErrStopped) {
+		t.Errorf("Add() error = %v, want %v", err, ErrStopped)
+	}
+}
+
+func TestSchedulerNext(t *testing.T) {
+	s := newTestScheduler(t)
+	n := &recordingNotifier{}
+	job, err := s.Add("job", Every(time.Hour), n, func(context.Context) error { return nil }, WithJitter(time.Minute))
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if next := job.Next(); !next.IsZero() {
+		t.Errorf("Next() before Start() = %v, want zero time", next)
+	}
+
+	before := time.Now()
+	s.Start()
+	waitFor(t, func() bool { return !job.Next().IsZero() })
+	next := job.Next()
+	if earliest, latest := before.Add(time.Hour), time.Now().Add(time.Hour+time.Minute); next.Before(earliest) || next.After(latest) {
+		t.Errorf("Next() = %v, want between %v and %v", next, earliest, latest)
+	}
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if next := job.Next(); !next.IsZero() {
+		t.Errorf("Next() after Shutdown() = %v, want zero time", next)
+	}
+}
+
+func TestSchedulerAddInvalid(t *testing.T) {
+	s := newTestScheduler(t)
+	n := &recordingNotifier{}
+	fn := func(context.Context) error { return nil }
+	if _, err := s.Add("job", Every(time.Hour), n, fn); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		job      string
+		schedule Schedule
+		notifier healthchecks.Notifier
+		fn       Func
+		opts     []JobOption
+	}{
+		{name: "empty name", job: "", schedule: Every(time.Hour), notifier: n, fn: fn},
+		{name: "duplicate name", job: "job", schedule: Every(time.Hour), notifier: n, fn: fn},
+		{name: "nil schedule", job: "a", schedule: nil, notifier: n, fn: fn},
+		{name: "nil notifier", job: "b", schedule: Every(time.Hour), notifier: nil, fn: fn},
+		{name: "nil func", job: "c", schedule: Every(time.Hour), notifier: n, fn: nil},
+		{name: "invalid jitter", job: "d", schedule: Every(time.Hour), notifier: n, fn: fn, opts: []JobOption{WithJitter(0)}},
+		{name: "invalid timeout", job: "e", schedule: Every(time.Hour), notifier: n, fn: fn, opts: []JobOption{WithJobTimeout(-1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Add(tt.job, tt.schedule, tt.notifier, tt.fn, tt.opts...); err == nil {
+				t.Error("Add() error = nil, want error")
+			}
+		})
+	}
+	if got := len(s.Jobs()); got != 1 {
+		t.Errorf("len(Jobs()) = %d, want 1", got)
+	}
+}
@@ -0,0 +1,228 @@
+//go:build unix
+
+package sdnotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stnokott/healthchecks"
+)
+
+// EnvNotifySocket is the environment variable pointing services to the notification socket.
+const EnvNotifySocket = "NOTIFY_SOCKET"
+
+// maxMessageSize is the maximum size of a single notification.
+const maxMessageSize = 4096
+
+// drainTimeout is the time notifications are still read after the context passed to [Bridge.Serve] is done,
+// so notifications sent right before a service exits aren't lost.
+const drainTimeout = 100 * time.Millisecond
+
+// Bridge receives sd_notify messages on a unix datagram socket and translates them into signals:
+//
+//   - READY=1 and WATCHDOG=1 send "success"
+//   - STATUS=<text> sends "log" with the text
+//   - STOPPING=1 and RELOADING=1 send "log"
+//   - WATCHDOG=trigger and ERRNO=<n> send "fail"
+//
+// Other variables are ignored. Consider [healthchecks.WithCoalescing] for services sending WATCHDOG=1 frequently.
+//
+// Use [Listen] for obtaining a new instance.
+type Bridge struct {
+	notifier healthchecks.Notifier
+	opts     *options
+
+	conn   *net.UnixConn
+	addr   string
+	tmpDir string // removed on Close, empty if the socket path was provided
+}
+
+// Listen creates the notification socket and returns a [Bridge] reporting to n.
+//
+// By default, the socket is created in a new temporary directory, see [WithSocketPath].
+// Call [Bridge.Serve] to process notifications and [Bridge.Close] to remove the socket.
+func Listen(n healthchecks.Notifier, opts ...Option) (*Bridge, error) {
+	if n == nil {
+		return nil, errors.New("notifier must not be nil")
+	}
+	options := defaultOptions()
+	for _, o := range opts {
+		if err := o.apply(options); err != nil {
+			return nil, fmt.Errorf("applying option: %w", err)
+		}
+	}
+
+	b := &Bridge{
+		notifier: n,
+		opts:     options,
+		addr:     options.SocketPath,
+	}
+	if b.addr == "" {
+		dir, err := os.MkdirTemp("", "hc-notify-")
+		if err != nil {
+			return nil, fmt.Errorf("creating socket directory: %w", err)
+		}
+		b.tmpDir = dir
+		b.addr = filepath.Join(dir, "notify.sock")
+	}
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: b.addr, Net: "unixgram"})
+	if err != nil {
+		b.removeTmpDir()
+		return nil, fmt.Errorf("listening on %s: %w", b.addr, err)
+	}
+	b.conn = conn
+	return b, nil
+}
+
+// Addr returns the path of the notification socket.
+func (b *Bridge) Addr() string {
+	return b.addr
+}
+
+// Env returns the environment variable to pass to the service, i.e. NOTIFY_SOCKET=<path>.
+func (b *Bridge) Env() string {
+	return EnvNotifySocket + "=" + b.addr
+}
+
+// Serve processes notifications until ctx is done or the bridge is closed.
+//
+// Signals which could not be sent are logged, see [WithLogger].
+func (b *Bridge) Serve(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		_ = b.conn.SetReadDeadline(time.Now().Add(drainTimeout))
+	})
+	defer stop()
+
+	buf := make([]byte, maxMessageSize)
+	for {
+		n, err := b.conn.Read(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("reading notification: %w", err)
+		}
+		// signals are sent even while draining
+		b.handle(context.WithoutCancel(ctx), string(buf[:n]))
+	}
+}
+
+// Close closes the socket and removes it.
+func (b *Bridge) Close() error {
+	err := b.conn.Close()
+	if b.tmpDir != "" {
+		b.removeTmpDir()
+	} else {
+		_ = os.Remove(b.addr)
+	}
+	return err
+}
+
+func (b *Bridge) removeTmpDir() {
+	if b.tmpDir != "" {
+		_ = os.RemoveAll(b.tmpDir)
+	}
+}
+
+// handle translates a single notification, which may contain multiple newline-separated assignments.
+func (b *Bridge) handle(ctx context.Context, msg string) {
+	var success, fail bool
+	var logs []string
+	for _, line := range strings.Split(msg, "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch {
+		case key == "READY" && value == "1", key == "WATCHDOG" && value == "1":
+			success = true
+		case key == "STATUS":
+			logs = append(logs, value)
+		case key == "STOPPING" && value == "1":
+			logs = append(logs, "stopping")
+		case key == "RELOADING" && value == "1":
+			logs = append(logs, "reloading")
+		case key == "WATCHDOG" && value == "trigger":
+			fail = true
+			logs = append(logs, "watchdog triggered")
+		case key == "ERRNO":
+			fail = true
+			logs = append(logs, "errno "+value)
+		}
+	}
+
+	for _, l := range logs {
+		b.send(ctx, healthchecks.SignalLog, func() error { return b.notifier.Log(ctx, l) })
+	}
+	switch {
+	case fail:
+		b.send(ctx, healthchecks.SignalFail, func() error { return b.notifier.Fail(ctx) })
+	case success:
+		b.send(ctx, healthchecks.SignalSuccess, func() error { return b.notifier.Success(ctx) })
+	}
+}
+
+func (b *Bridge) send(ctx context.Context, sig healthchecks.Signal, fn func() error) {
+	if err := fn(); err != nil {
+		b.opts.Logger.ErrorContext(ctx, "sending signal", "signal", sig, "error", err)
+	}
+}
+
+// Run runs cmd with NOTIFY_SOCKET pointing to a new [Bridge] reporting to n, until cmd exits.
+//
+// It sends "start" when spawning cmd and reports its exit code via "exit-status" afterwards.
+// If cmd is terminated by a signal, "fail" is sent instead.
+// cmd must not have been started yet. Use [exec.CommandContext] for stopping it.
+//
+// The returned exit code is -1 if cmd could not be started or was terminated by a signal.
+func Run(ctx context.Context, n healthchecks.Notifier, cmd *exec.Cmd, opts ...Option) (int, error) {
+	b, err := Listen(n, opts...)
+	if err != nil {
+		return -1, err
+	}
+	defer func() {
+		_ = b.Close()
+	}()
+	cmd.Env = append(cmd.Environ(), b.Env())
+
+	// signals are sent even if ctx is cancelled, since it usually stops cmd
+	pingCtx := context.WithoutCancel(ctx)
+	b.send(pingCtx, healthchecks.SignalStart, func() error { return n.Start(pingCtx) })
+	if err := cmd.Start(); err != nil {
+		b.send(pingCtx, healthchecks.SignalFail, func() error { return healthchecks.FailWithBody(pingCtx, n, "starting command: "+err.Error()) })
+		return -1, fmt.Errorf("starting command: %w", err)
+	}
+
+	serveCtx, stopServe := context.WithCancel(ctx)
+	served := make(chan error, 1)
+	go func() {
+		served <- b.Serve(serveCtx)
+	}()
+
+	waitErr := cmd.Wait()
+	stopServe()
+	serveErr := <-served
+
+	code := cmd.ProcessState.ExitCode()
+	if code >= 0 {
+		b.send(pingCtx, healthchecks.SignalExitStatus(code), func() error { return n.ExitStatus(pingCtx, code) })
+	} else {
+		msg := "command terminated: " + cmd.ProcessState.String()
+		b.send(pingCtx, healthchecks.SignalFail, func() error { return healthchecks.FailWithBody(pingCtx, n, msg) })
+	}
+
+	var exitErr *exec.ExitError
+	if waitErr != nil && !errors.As(waitErr, &exitErr) {
+		return code, fmt.Errorf("waiting for command: %w", waitErr)
+	}
+	return code, serveErr
+}
@@ -0,0 +1,221 @@
+//go:build unix
+
+package sdnotify
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"os/exec"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingNotifier records all signals in the order they are received.
+type recordingNotifier struct {
+	mu      sync.Mutex
+	signals []string
+}
+
+func (r *recordingNotifier) record(s string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.signals = append(r.signals, s)
+	return nil
+}
+
+func (r *recordingNotifier) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.signals...)
+}
+
+func (r *recordingNotifier) Start(context.Context) error   { return r.record("start") }
+func (r *recordingNotifier) Success(context.Context) error { return r.record("success") }
+func (r *recordingNotifier) Fail(context.Context) error    { return r.record("fail") }
+func (r *recordingNotifier) Log(_ context.Context, msg string) error {
+	return r.record("log:" + msg)
+}
+func (r *recordingNotifier) ExitStatus(_ context.Context, code int) error {
+	return r.record("exit-status:" + strconv.Itoa(code))
+}
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func notify(t *testing.T, addr string, msg string) {
+	t.Helper()
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: addr, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(msg)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBridgeServe(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want []string
+	}{
+		{name: "ready", msg: "READY=1", want: []string{"success"}},
+		{name: "watchdog", msg: "WATCHDOG=1", want: []string{"success"}},
+		{name: "ready and status", msg: "READY=1\nSTATUS=Processing requests", want: []string{"log:Processing requests", "success"}},
+		{name: "stopping", msg: "STOPPING=1", want: []string{"log:stopping"}},
+		{name: "reloading", msg: "RELOADING=1\nMONOTONIC_USEC=123", want: []string{"log:reloading"}},
+		{name: "watchdog trigger", msg: "WATCHDOG=trigger", want: []string{"log:watchdog triggered", "fail"}},
+		{name: "errno", msg: "STATUS=Failed to start\nERRNO=2", want: []string{"log:Failed to start", "log:errno 2", "fail"}},
+		{name: "ignored", msg: "MAINPID=4711\nFDSTORE=1\ngarbage", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &recordingNotifier{}
+			b, err := Listen(n, WithLogger(testLogger))
+			if err != nil {
+				t.Fatalf("Listen() error = %v", err)
+			}
+			defer b.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			served := make(chan error, 1)
+			go func() {
+				served <- b.Serve(ctx)
+			}()
+			notify(t, b.Addr(), tt.msg)
+			// marks the end of the messages under test
+			notify(t, b.Addr(), "STATUS=end")
+			deadline := time.Now().Add(5 * time.Second)
+			for got := n.recorded(); len(got) == 0 || got[len(got)-1] != "log:end"; got = n.recorded() {
+				if time.Now().After(deadline) {
+					t.Fatalf("signals = %v, missing end marker", got)
+				}
+				time.Sleep(5 * time.Millisecond)
+			}
+			cancel()
+			if err := <-served; err != nil {
+				t.Fatalf("Serve() error = %v", err)
+			}
+
+			got := n.recorded()
+			got = got[:len(got)-1]
+			if len(got) == 0 {
+				got = nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("signals = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBridgeClose(t *testing.T) {
+	b, err := Listen(&recordingNotifier{}, WithLogger(testLogger))
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	if want := "NOTIFY_SOCKET=" + b.Addr(); b.Env() != want {
+		t.Errorf("Env() = %s, want %s", b.Env(), want)
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- b.Serve(context.Background())
+	}()
+	if err := b.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("Serve() after Close() error = %v", err)
+	}
+	if _, err := os.Stat(b.Addr()); !os.IsNotExist(err) {
+		t.Errorf("socket still exists after Close(): %v", err)
+	}
+}
+
+// TestHelperProcess is not a real test, it acts as a service for TestRun.
+// It sends each argument after "--" as notification and exits with the code from HC_HELPER_EXIT_CODE.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("HC_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	addr := os.Getenv(EnvNotifySocket)
+	for _, msg := range args[1:] {
+		conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: addr, Net: "unixgram"})
+		if err != nil {
+			os.Exit(100)
+		}
+		_, _ = conn.Write([]byte(msg))
+		_ = conn.Close()
+	}
+	code, _ := strconv.Atoi(os.Getenv("HC_HELPER_EXIT_CODE"))
+	os.Exit(code)
+}
+
+func helperCommand(code int, msgs ...string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], append([]string{"-test.run=TestHelperProcess", "--"}, msgs...)...)
+	cmd.Env = append(os.Environ(), "HC_WANT_HELPER_PROCESS=1", "HC_HELPER_EXIT_CODE="+strconv.Itoa(code))
+	return cmd
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmd      *exec.Cmd
+		wantCode int
+		want     []string
+	}{
+		{
+			name:     "clean exit",
+			cmd:      helperCommand(0, "READY=1", "STOPPING=1"),
+			wantCode: 0,
+			want:     []string{"start", "success", "log:stopping", "exit-status:0"},
+		},
+		{
+			name:     "failure",
+			cmd:      helperCommand(3, "STATUS=cannot connect"),
+			wantCode: 3,
+			want:     []string{"start", "log:cannot connect", "exit-status:3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &recordingNotifier{}
+			code, err := Run(context.Background(), n, tt.cmd, WithLogger(testLogger))
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if code != tt.wantCode {
+				t.Errorf("Run() code = %d, want %d", code, tt.wantCode)
+			}
+			if got := n.recorded(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("signals = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunNotStarted(t *testing.T) {
+	n := &recordingNotifier{}
+	code, err := Run(context.Background(), n, exec.Command("/nonexistent/command"), WithLogger(testLogger))
+	if err == nil {
+		t.Fatal("Run() error = nil, want error")
+	}
+	if code != -1 {
+		t.Errorf("Run() code = %d, want -1", code)
+	}
+	got := n.recorded()
+	if len(got) != 3 || got[0] != "start" || got[2] != "fail" {
+		t.Errorf("signals = %v, want start, log and fail", got)
+	}
+}
@@ -0,0 +1,7 @@
+// Package sdnotify bridges the systemd notification protocol (sd_notify) to healthchecks.io.
+//
+// A [Bridge] listens on a unix datagram socket, which is passed to a service via the NOTIFY_SOCKET environment variable.
+// Services which already notify systemd about their state can thus be monitored without code changes.
+//
+// The package is only available on unix systems.
+package sdnotify
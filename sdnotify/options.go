@@ -0,0 +1,64 @@
+//go:build unix
+
+package sdnotify
+
+import (
+	"errors"
+	"log/slog"
+)
+
+type options struct {
+	SocketPath string
+	Logger     *slog.Logger
+}
+
+func defaultOptions() *options {
+	return &options{
+		Logger: slog.Default(),
+	}
+}
+
+// Option applies a configuration option to a [Bridge].
+type Option interface {
+	apply(opts *options) error
+}
+
+type socketPathOption string
+
+var _ Option = socketPathOption("")
+
+func (p socketPathOption) apply(opts *options) error {
+	if p == "" {
+		return errors.New("socket path must not be empty")
+	}
+	opts.SocketPath = string(p)
+	return nil
+}
+
+// WithSocketPath creates the socket at path instead of a new temporary directory.
+//
+// The path must not exist yet and is removed by [Bridge.Close].
+func WithSocketPath(path string) Option {
+	return socketPathOption(path)
+}
+
+type loggerOption struct {
+	logger *slog.Logger
+}
+
+var _ Option = loggerOption{}
+
+func (l loggerOption) apply(opts *options) error {
+	if l.logger == nil {
+		return errors.New("logger must not be nil")
+	}
+	opts.Logger = l.logger
+	return nil
+}
+
+// WithLogger sets the logger for signals which could not be sent.
+//
+// Defaults to [slog.Default].
+func WithLogger(l *slog.Logger) Option {
+	return loggerOption{logger: l}
+}
@@ -0,0 +1,141 @@
+package healthchecks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type sendOptions struct {
+	body    io.Reader
+	runID   string
+	create  bool
+	timeout time.Duration
+	noRetry bool
+}
+
+func sendOptsFrom(opts []SendOption) (sendOptions, error) {
+	var s sendOptions
+	for _, o := range opts {
+		if err := o.applySend(&s); err != nil {
+			return sendOptions{}, fmt.Errorf("applying send option: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// Sender is implemented by notifiers which accept signals with options, like [Check].
+type Sender interface {
+	// Send sends sig to the check.
+	Send(ctx context.Context, sig Signal, opts ...SendOption) error
+}
+
+// FailWithBody sends the "fail" signal to n with body attached, e.g. the output of a failed job.
+//
+// If n doesn't implement [Sender], body is sent via [Notifier.Log] first and opts are ignored.
+// An empty body is not sent.
+func FailWithBody(ctx context.Context, n Notifier, body string, opts ...SendOption) error {
+	if s, ok := n.(Sender); ok {
+		if body != "" {
+			opts = append(opts[:len(opts):len(opts)], WithBody(body))
+		}
+		return s.Send(ctx, SignalFail, opts...)
+	}
+	if body != "" {
+		if err := n.Log(ctx, body); err != nil {
+			return err
+		}
+	}
+	return n.Fail(ctx)
+}
+
+// SendOption applies a configuration option to a single signal sent via Send.
+type SendOption interface {
+	applySend(opts *sendOptions) error
+}
+
+type bodyOption string
+
+var _ SendOption = bodyOption("")
+
+func (b bodyOption) applySend(opts *sendOptions) error {
+	opts.body = strings.NewReader(string(b))
+	return nil
+}
+
+// WithBody attaches a body to the signal, e.g. the message of [SignalLog] or the output of a failed job.
+func WithBody(body string) SendOption {
+	return bodyOption(body)
+}
+
+type callRunIDOption string
+
+var _ SendOption = callRunIDOption("")
+
+func (r callRunIDOption) applySend(opts *sendOptions) error {
+	if err := validateUUID(string(r)); err != nil {
+		return fmt.Errorf("run ID: %w", err)
+	}
+	opts.runID = string(r)
+	return nil
+}
+
+// WithCallRunID attaches a run ID to the signal, overriding [WithRunID].
+func WithCallRunID(rid string) SendOption {
+	return callRunIDOption(rid)
+}
+
+type callCreateOption struct{}
+
+var _ SendOption = callCreateOption{}
+
+func (callCreateOption) applySend(opts *sendOptions) error {
+	opts.create = true
+	return nil
+}
+
+// WithCallAutoCreate creates the check if it doesn't exist yet, like [WithAutoCreate] for a single signal.
+func WithCallAutoCreate() SendOption {
+	return callCreateOption{}
+}
+
+type callTimeoutOption time.Duration
+
+var _ SendOption = callTimeoutOption(0)
+
+func (t callTimeoutOption) applySend(opts *sendOptions) error {
+	if t <= 0 {
+		return fmt.Errorf("timeout is %d, needs to be > 0", t)
+	}
+	opts.timeout = time.Duration(t)
+	return nil
+}
+
+// WithCallTimeout limits the duration of sending the signal, overriding [WithTimeout].
+// It can be longer than the timeout of the check, e.g. for large bodies.
+//
+// The timeout applies to each endpoint tried (see [WithURLs]).
+// A timeout of a client set via [WithHTTPClient] applies in addition.
+func WithCallTimeout(t time.Duration) SendOption {
+	return callTimeoutOption(t)
+}
+
+type noRetryOption struct{}
+
+var _ SendOption = noRetryOption{}
+
+func (noRetryOption) applySend(opts *sendOptions) error {
+	opts.noRetry = true
+	return nil
+}
+
+// WithoutRetries sends the signal at most once: neither is a reset keep-alive connection retried,
+// nor are other endpoints tried (see [WithURLs]).
+//
+// Since the server may have received a signal before resetting the connection, a retried signal may arrive twice.
+// Use WithoutRetries for signals where this matters, e.g. log messages which must not be duplicated.
+func WithoutRetries() SendOption {
+	return noRetryOption{}
+}
@@ -0,0 +1,187 @@
+package healthchecks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckSend(t *testing.T) {
+	type received struct {
+		method string
+		uri    string
+		body   string
+	}
+	var got received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got = received{method: r.Method, uri: r.URL.RequestURI(), body: string(b)}
+		_, _ = io.WriteString(w, "OK")
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		sig     Signal
+		opts    []SendOption
+		want    received
+		wantErr bool
+	}{
+		{
+			name: "success",
+			sig:  SignalSuccess,
+			opts: []SendOption{},
+			want: received{method: "GET", uri: "/rk9bbOJREu6nOWeHGjlnDQ/sample-check"},
+		},
+		{
+			name: "fail with body",
+			sig:  SignalFail,
+			opts: []SendOption{WithBody("foo")},
+			want: received{method: "POST", uri: "/rk9bbOJREu6nOWeHGjlnDQ/sample-check/fail", body: "foo"},
+		},
+		{
+			name: "start with run ID and create",
+			sig:  SignalStart,
+			opts: []SendOption{WithCallRunID("0b1c1d2e-3f40-4152-8637-48495a6b7c8d"), WithCallAutoCreate()},
+			want: received{method: "GET", uri: "/rk9bbOJREu6nOWeHGjlnDQ/sample-check/start?create=1&rid=0b1c1d2e-3f40-4152-8637-48495a6b7c8d"},
+		},
+		{
+			name:    "invalid run ID",
+			sig:     SignalStart,
+			opts:    []SendOption{WithCallRunID("foo")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid timeout",
+			sig:     SignalStart,
+			opts:    []SendOption{WithCallTimeout(0)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = received{}
+			p, err := NewProject("rk9bbOJREu6nOWeHGjlnDQ", WithURL(server.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := p.Slug("sample-check").(*Check).Send(context.Background(), tt.sig, tt.opts...); (err != nil) != tt.wantErr {
+				t.Errorf("Check.Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Check.Send() sent %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectSendTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+		_, _ = io.WriteString(w, "OK")
+	}))
+	defer server.Close()
+
+	p, err := NewProject("rk9bbOJREu6nOWeHGjlnDQ", WithURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = p.Send(context.Background(), "sample-check", SignalStart, WithCallTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Project.Send() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestCheckSendTimeoutOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+		_, _ = io.WriteString(w, "OK")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Timeout: time.Minute}
+	c, err := NewUUID("6da9bc25-880d-4a73-a0e5-e833405e206f", WithURL(server.URL), WithHTTPClient(client), WithTimeout(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Timeout != time.Minute {
+		t.Errorf("HTTP client timeout = %s, want %s", client.Timeout, time.Minute)
+	}
+
+	ctx := context.Background()
+	if err := c.Start(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Start() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := c.Send(ctx, SignalLog, WithBody("large body"), WithCallTimeout(time.Second)); err != nil {
+		t.Errorf("Send() with longer call timeout error = %v, want nil", err)
+	}
+}
+
+func TestCheckSendWithoutRetries(t *testing.T) {
+	var requests []string
+	handler := func(name string, status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, name)
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, "OK")
+		}
+	}
+	primary := httptest.NewServer(handler("primary", http.StatusServiceUnavailable))
+	defer primary.Close()
+	secondary := httptest.NewServer(handler("secondary", http.StatusOK))
+	defer secondary.Close()
+
+	c, err := NewUUID("6da9bc25-880d-4a73-a0e5-e833405e206f", WithURLs(primary.URL, secondary.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Send(context.Background(), SignalSuccess, WithoutRetries()); err == nil {
+		t.Error("Send() error = nil, want error of primary")
+	}
+	if want := []string{"primary"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestFailWithBody(t *testing.T) {
+	buf := new(bytes.Buffer)
+	check, err := NewUUID("6da9bc25-880d-4a73-a0e5-e833405e206f", WithDryRun(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := FailWithBody(context.Background(), check, "job failed", WithCallRunID("0b1c1d2e-3f40-4152-8637-48495a6b7c8d")); err != nil {
+		t.Fatalf("FailWithBody() error = %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "signal=fail method=POST") || !strings.Contains(got, "rid=0b1c1d2e") {
+		t.Errorf("FailWithBody() sent %q, want fail signal with body and run ID", got)
+	}
+
+	n := new(recordingNotifier)
+	if err := FailWithBody(context.Background(), n, "job failed"); err != nil {
+		t.Fatalf("FailWithBody() error = %v", err)
+	}
+	if got, want := n.messages(), []string{"job failed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FailWithBody() logged %q, want %q", got, want)
+	}
+
+	n = new(recordingNotifier)
+	if err := FailWithBody(context.Background(), n, ""); err != nil {
+		t.Fatalf("FailWithBody() error = %v", err)
+	}
+	if got := n.messages(); len(got) != 0 {
+		t.Errorf("FailWithBody() with empty body logged %q, want nothing", got)
+	}
+}
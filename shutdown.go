@@ -0,0 +1,92 @@
+package healthchecks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// shutdownTimeout limits reporting a received signal, so a hanging request doesn't delay the shutdown.
+var shutdownTimeout = 5 * time.Second
+
+// signalNames maps common OS signals to their conventional names, which [syscall.Signal.String] doesn't provide.
+var signalNames = map[os.Signal]string{
+	syscall.SIGHUP:  "SIGHUP",
+	syscall.SIGINT:  "SIGINT",
+	syscall.SIGQUIT: "SIGQUIT",
+	syscall.SIGABRT: "SIGABRT",
+	syscall.SIGTERM: "SIGTERM",
+}
+
+// NotifyOnSignals reports the receipt of one of the OS signals sigs to n via [Notifier.Log],
+// including the signal's name and the uptime since calling NotifyOnSignals.
+//
+// It returns a copy of ctx which is cancelled once the signal has been reported, so the normal shutdown can proceed,
+// or when the returned stop function is called, whichever happens first.
+// Reporting isn't aborted by cancelling ctx, but limited to 5s, so a hanging request doesn't delay the shutdown.
+// [context.Cause] of the returned context describes the received signal and any error which occurred while reporting it.
+//
+// Only the first signal is handled. Afterwards, the default behavior is restored,
+// so a repeated signal (e.g. pressing Ctrl+C twice) terminates the process immediately.
+//
+// Call stop to release resources once the signals don't need to be handled anymore.
+func NotifyOnSignals(ctx context.Context, n Notifier, sigs ...os.Signal) (notifyCtx context.Context, stop context.CancelFunc) {
+	return notifyOnSignals(ctx, n, SignalLog, sigs)
+}
+
+// FailOnSignals is like [NotifyOnSignals], but reports the receipt of a signal via the "fail" signal.
+//
+// If n supports attaching a body (like [Check]), the signal's name and the uptime are attached to the "fail" signal.
+// Otherwise, they are sent via [Notifier.Log] first.
+func FailOnSignals(ctx context.Context, n Notifier, sigs ...os.Signal) (notifyCtx context.Context, stop context.CancelFunc) {
+	return notifyOnSignals(ctx, n, SignalFail, sigs)
+}
+
+func notifyOnSignals(ctx context.Context, n Notifier, report Signal, sigs []os.Signal) (context.Context, context.CancelFunc) {
+	started := time.Now()
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+
+	go func() {
+		defer signal.Stop(ch)
+
+		select {
+		case <-ctx.Done():
+		case s := <-ch:
+			signal.Stop(ch)
+			cause := fmt.Errorf("received %s", signalName(s))
+			msg := fmt.Sprintf("%s after %s uptime", cause, time.Since(started).Round(time.Second))
+			// report even if the parent context is cancelled concurrently
+			sendCtx, cancelSend := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+			if err := sendShutdown(sendCtx, n, report, msg); err != nil {
+				cause = errors.Join(cause, fmt.Errorf("reporting signal: %w", err))
+			}
+			cancelSend()
+			cancel(cause)
+		}
+	}()
+
+	return ctx, func() {
+		cancel(context.Canceled)
+	}
+}
+
+func sendShutdown(ctx context.Context, n Notifier, report Signal, msg string) error {
+	if report == SignalLog {
+		return n.Log(ctx, msg)
+	}
+	return FailWithBody(ctx, n, msg)
+}
+
+func signalName(s os.Signal) string {
+	if name, ok := signalNames[s]; ok {
+		return name + " (" + s.String() + ")"
+	}
+	return s.String()
+}
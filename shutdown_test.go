@@ -0,0 +1,110 @@
+package healthchecks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyOnSignals(t *testing.T) {
+	n := new(recordingNotifier)
+	ctx, stop := NotifyOnSignals(context.Background(), n, syscall.SIGHUP)
+	defer stop()
+
+	raise(t, syscall.SIGHUP)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context not cancelled after receiving signal")
+	}
+
+	logs := n.messages()
+	if len(logs) != 1 || !strings.HasPrefix(logs[0], "received SIGHUP (hangup) after ") {
+		t.Errorf("NotifyOnSignals() logged %q, want message about SIGHUP", logs)
+	}
+	if cause := context.Cause(ctx); cause == nil || !strings.Contains(cause.Error(), "SIGHUP") {
+		t.Errorf("context.Cause() = %v, want SIGHUP", cause)
+	}
+}
+
+func TestFailOnSignals(t *testing.T) {
+	buf := new(bytes.Buffer)
+	check, err := NewUUID("6da9bc25-880d-4a73-a0e5-e833405e206f", WithDryRun(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, stop := FailOnSignals(context.Background(), check, syscall.SIGHUP)
+	defer stop()
+
+	raise(t, syscall.SIGHUP)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context not cancelled after receiving signal")
+	}
+
+	if got := buf.String(); !strings.Contains(got, "signal=fail method=POST") || !strings.Contains(got, "received SIGHUP") {
+		t.Errorf("FailOnSignals() sent %q, want fail signal with SIGHUP in body", got)
+	}
+}
+
+func TestNotifyOnSignalsStop(t *testing.T) {
+	n := new(recordingNotifier)
+	ctx, stop := NotifyOnSignals(context.Background(), n, syscall.SIGHUP)
+	stop()
+
+	if !errors.Is(context.Cause(ctx), context.Canceled) {
+		t.Errorf("context.Cause() = %v, want %v", context.Cause(ctx), context.Canceled)
+	}
+	if logs := n.messages(); len(logs) != 0 {
+		t.Errorf("NotifyOnSignals() logged %q after stop, want nothing", logs)
+	}
+}
+
+// hangingNotifier blocks in Log until ctx is done.
+type hangingNotifier struct {
+	recordingNotifier
+}
+
+func (h *hangingNotifier) Log(ctx context.Context, _ string) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestNotifyOnSignalsTimeout(t *testing.T) {
+	defer func(d time.Duration) { shutdownTimeout = d }(shutdownTimeout)
+	shutdownTimeout = 10 * time.Millisecond
+
+	ctx, stop := NotifyOnSignals(context.Background(), new(hangingNotifier), syscall.SIGHUP)
+	defer stop()
+
+	raise(t, syscall.SIGHUP)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context not cancelled after reporting timed out")
+	}
+	if cause := context.Cause(ctx); !errors.Is(cause, context.DeadlineExceeded) {
+		t.Errorf("context.Cause() = %v, want %v", cause, context.DeadlineExceeded)
+	}
+}
+
+func raise(t *testing.T, sig os.Signal) {
+	t.Helper()
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Signal(sig); err != nil {
+		t.Skipf("sending %s not supported: %v", sig, err)
+	}
+}
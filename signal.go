@@ -0,0 +1,139 @@
+package healthchecks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type signalKind int
+
+const (
+	kindSuccess signalKind = iota
+	kindStart
+	kindFail
+	kindLog
+	kindExitStatus
+)
+
+// Signal is a ping sent to a check, e.g. [SignalSuccess].
+//
+// Signals can be sent using the Send methods of [Check] and [Project].
+// They implement [encoding.TextMarshaler] and [encoding.TextUnmarshaler],
+// so they can be stored in configuration files, queues or logs using their names (see [ParseSignal]).
+//
+// The zero value is [SignalSuccess].
+type Signal struct {
+	kind signalKind
+	code int // exit status, only used for kindExitStatus
+}
+
+var (
+	// SignalSuccess signals a successful run of the check.
+	SignalSuccess = Signal{kind: kindSuccess}
+	// SignalStart signals the start of a run of the check.
+	SignalStart = Signal{kind: kindStart}
+	// SignalFail signals a failed run of the check.
+	SignalFail = Signal{kind: kindFail}
+	// SignalLog attaches a message to the check without changing its state.
+	SignalLog = Signal{kind: kindLog}
+)
+
+// SignalExitStatus signals the end of a run of the check with an exit status.
+//
+// Success or failure of the check is determined by the exit code.
+func SignalExitStatus(code int) Signal {
+	return Signal{kind: kindExitStatus, code: code}
+}
+
+// suffix returns the path segments appended to the check's path.
+func (s Signal) suffix() []string {
+	switch s.kind {
+	case kindStart:
+		return []string{"/start"}
+	case kindFail:
+		return []string{"/fail"}
+	case kindLog:
+		return []string{"/log"}
+	case kindExitStatus:
+		return []string{"/", strconv.Itoa(s.code)}
+	default:
+		return nil
+	}
+}
+
+// terminal reports whether s concludes a run of the check, i.e. it is "success", "fail" or "exit-status".
+func (s Signal) terminal() bool {
+	switch s.kind {
+	case kindSuccess, kindFail, kindExitStatus:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the name of the signal, e.g. "success" or "exit-status:1".
+// It is the inverse of [ParseSignal].
+func (s Signal) String() string {
+	switch s.kind {
+	case kindSuccess:
+		return "success"
+	case kindStart:
+		return "start"
+	case kindFail:
+		return "fail"
+	case kindLog:
+		return "log"
+	case kindExitStatus:
+		return exitStatusPrefix + strconv.Itoa(s.code)
+	default:
+		return "unknown"
+	}
+}
+
+const exitStatusPrefix = "exit-status:"
+
+// ParseSignal parses the name of a signal as returned by [Signal.String].
+//
+// Valid names are "success", "start", "fail", "log" and "exit-status:<code>".
+func ParseSignal(name string) (Signal, error) {
+	switch name {
+	case "success":
+		return SignalSuccess, nil
+	case "start":
+		return SignalStart, nil
+	case "fail":
+		return SignalFail, nil
+	case "log":
+		return SignalLog, nil
+	}
+	if codeStr, ok := strings.CutPrefix(name, exitStatusPrefix); ok {
+		code, err := strconv.Atoi(codeStr)
+		if err != nil {
+			return Signal{}, fmt.Errorf("invalid exit status '%s': %w", codeStr, err)
+		}
+		return SignalExitStatus(code), nil
+	}
+	return Signal{}, fmt.Errorf("unknown signal '%s'", name)
+}
+
+// ExitCode returns the exit code of an "exit-status" signal.
+// The second return value reports whether s is an "exit-status" signal.
+func (s Signal) ExitCode() (int, bool) {
+	return s.code, s.kind == kindExitStatus
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (s Signal) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (s *Signal) UnmarshalText(text []byte) error {
+	parsed, err := ParseSignal(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
@@ -0,0 +1,63 @@
+package healthchecks
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseSignal(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Signal
+		wantErr bool
+	}{
+		{name: "success", want: SignalSuccess, wantErr: false},
+		{name: "start", want: SignalStart, wantErr: false},
+		{name: "fail", want: SignalFail, wantErr: false},
+		{name: "log", want: SignalLog, wantErr: false},
+		{name: "exit-status:0", want: SignalExitStatus(0), wantErr: false},
+		{name: "exit-status:143", want: SignalExitStatus(143), wantErr: false},
+		{name: "exit-status:foo", want: Signal{}, wantErr: true},
+		{name: "exit-status", want: Signal{}, wantErr: true},
+		{name: "Success", want: Signal{}, wantErr: true},
+		{name: "", want: Signal{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSignal(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSignal(%s) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseSignal(%s) = %v, want %v", tt.name, got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.name {
+				t.Errorf("ParseSignal(%s).String() = %s, want %s", tt.name, got.String(), tt.name)
+			}
+		})
+	}
+}
+
+func TestSignalJSON(t *testing.T) {
+	want := []SlugSignal{
+		{Slug: "foo", Signal: SignalStart},
+		{Slug: "bar", Signal: SignalExitStatus(3), Body: "baz"},
+	}
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); s != `[{"Slug":"foo","Signal":"start","Body":""},{"Slug":"bar","Signal":"exit-status:3","Body":"baz"}]` {
+		t.Errorf("json.Marshal() = %s", s)
+	}
+
+	var got []SlugSignal
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Unmarshal() = %v, want %v", got, want)
+	}
+}
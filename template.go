@@ -0,0 +1,153 @@
+package healthchecks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// URLTemplates are the URLs requested by [TemplateBackend] per signal.
+//
+// They may contain the following placeholders, which are escaped according to their position in the URL:
+//
+//	$SIGNAL   name of the signal, i.e. success, start, fail, log or exit-status
+//	$CODE     exit code of "exit-status" signals, 0 for "success" and 1 for "fail"
+//	$RUN_ID   run ID of the signal (see [WithRunID])
+//	$MESSAGE  body of the signal
+//
+// Signals with an empty template are skipped. If ExitStatus is empty, "exit-status" signals use
+// the Success template for exit code 0 and the Fail template otherwise.
+//
+// Keep secrets in the path of the URLs, since only the path is redacted in errors and dry runs.
+type URLTemplates struct {
+	Success    string
+	Start      string
+	Fail       string
+	Log        string
+	ExitStatus string
+}
+
+// compile-time interface implementation check
+var _ Backend = (*TemplateBackend)(nil)
+
+// TemplateBackend sends signals to services pinged via plain URLs, e.g. Cronitor or Dead Man's Snitch.
+//
+// Signals with a body are sent as POST with the body attached, unless the template contains $MESSAGE.
+// Any 2xx response status is considered a success.
+//
+// Use [NewTemplateBackend] for obtaining a new instance.
+type TemplateBackend struct {
+	templates URLTemplates
+}
+
+// NewTemplateBackend creates a new instance of [TemplateBackend].
+//
+// At least the Success template is required.
+func NewTemplateBackend(t URLTemplates) (*TemplateBackend, error) {
+	if t.Success == "" {
+		return nil, errors.New("success template must not be empty")
+	}
+	for _, tmpl := range []struct {
+		name, tmpl string
+	}{
+		{"success", t.Success},
+		{"start", t.Start},
+		{"fail", t.Fail},
+		{"log", t.Log},
+		{"exit-status", t.ExitStatus},
+	} {
+		if tmpl.tmpl == "" {
+			continue
+		}
+		parsed, err := parseURL(expandTemplate(tmpl.tmpl, Ping{}))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s template: %w", tmpl.name, err)
+		}
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid %s template: missing scheme or host", tmpl.name)
+		}
+	}
+	return &TemplateBackend{templates: t}, nil
+}
+
+// template returns the template for sig, empty if sig is not supported.
+func (b *TemplateBackend) template(sig Signal) string {
+	switch sig.kind {
+	case kindStart:
+		return b.templates.Start
+	case kindFail:
+		return b.templates.Fail
+	case kindLog:
+		return b.templates.Log
+	case kindExitStatus:
+		switch {
+		case b.templates.ExitStatus != "":
+			return b.templates.ExitStatus
+		case sig.code == 0:
+			return b.templates.Success
+		default:
+			return b.templates.Fail
+		}
+	default:
+		return b.templates.Success
+	}
+}
+
+// NewRequest implements [Backend].
+func (b *TemplateBackend) NewRequest(ctx context.Context, p Ping) (*http.Request, error) {
+	tmpl := b.template(p.Signal)
+	if tmpl == "" {
+		return nil, ErrUnsupportedSignal
+	}
+	u := expandTemplate(tmpl, p)
+	if strings.Contains(tmpl, "$MESSAGE") {
+		return http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	}
+	return newRequest(ctx, u, p.Body)
+}
+
+// CheckResponse implements [Backend].
+func (b *TemplateBackend) CheckResponse(resp *http.Response, body []byte) error {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("HTTP response status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
+// expandTemplate replaces the placeholders in tmpl with the values of p.
+func expandTemplate(tmpl string, p Ping) string {
+	name, _, _ := strings.Cut(p.Signal.String(), ":")
+	var code string
+	switch p.Signal.kind {
+	case kindSuccess:
+		code = "0"
+	case kindFail:
+		code = "1"
+	case kindExitStatus:
+		code = strconv.Itoa(p.Signal.code)
+	}
+	values := []string{
+		"$SIGNAL", name,
+		"$CODE", code,
+		"$RUN_ID", p.RunID,
+		"$MESSAGE", truncate(string(p.Body), maxMessageInURL),
+	}
+	escaped := func(escape func(string) string) *strings.Replacer {
+		pairs := make([]string, len(values))
+		for i := 0; i < len(values); i += 2 {
+			pairs[i], pairs[i+1] = values[i], escape(values[i+1])
+		}
+		return strings.NewReplacer(pairs...)
+	}
+
+	path, query, hasQuery := strings.Cut(tmpl, "?")
+	expanded := escaped(url.PathEscape).Replace(path)
+	if hasQuery {
+		expanded += "?" + escaped(url.QueryEscape).Replace(query)
+	}
+	return expanded
+}
@@ -0,0 +1,126 @@
+package healthchecks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestTemplateBackend(t *testing.T) {
+	type request struct {
+		method, uri, body string
+	}
+	var got []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, request{method: r.Method, uri: r.URL.RequestURI(), body: string(body)})
+		if r.URL.Query().Get("state") == "broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	b, err := NewTemplateBackend(URLTemplates{
+		Start:   srv.URL + "/p/key/job?state=run&series=$RUN_ID",
+		Success: srv.URL + "/p/key/job?state=complete&series=$RUN_ID",
+		Fail:    srv.URL + "/p/key/job?state=fail&status_code=$CODE&message=$MESSAGE",
+		Log:     srv.URL + "/log/$SIGNAL",
+	})
+	if err != nil {
+		t.Fatalf("NewTemplateBackend() error = %v", err)
+	}
+	c, err := NewCheck(b)
+	if err != nil {
+		t.Fatalf("NewCheck() error = %v", err)
+	}
+	ctx := context.Background()
+	rid := "6da9bc25-880d-4a73-a0e5-e833405e206f"
+	for _, send := range []func() error{
+		func() error { return c.Send(ctx, SignalStart, WithCallRunID(rid)) },
+		func() error { return c.Send(ctx, SignalSuccess, WithCallRunID(rid)) },
+		func() error { return c.Send(ctx, SignalFail, WithBody("disk full & more")) },
+		func() error { return c.ExitStatus(ctx, 3) },
+		func() error { return c.Log(ctx, "a message") },
+	} {
+		if err := send(); err != nil {
+			t.Errorf("signal error = %v", err)
+		}
+	}
+
+	want := []request{
+		{method: http.MethodGet, uri: "/p/key/job?state=run&series=" + rid},
+		{method: http.MethodGet, uri: "/p/key/job?state=complete&series=" + rid},
+		{method: http.MethodGet, uri: "/p/key/job?state=fail&status_code=1&message=disk+full+%26+more"},
+		{method: http.MethodGet, uri: "/p/key/job?state=fail&status_code=3&message="},
+		{method: http.MethodPost, uri: "/log/log", body: "a message"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("requests = %+v, want %+v", got, want)
+	}
+
+	b, err = NewTemplateBackend(URLTemplates{Success: srv.URL + "/p/key/job?state=broken"})
+	if err != nil {
+		t.Fatalf("NewTemplateBackend() error = %v", err)
+	}
+	c, err = NewCheck(b)
+	if err != nil {
+		t.Fatalf("NewCheck() error = %v", err)
+	}
+	if err := c.Success(ctx); err == nil {
+		t.Error("Success() error = nil, want error for status 500")
+	}
+	// exit code 0 falls back to the success template, unsupported signals are skipped
+	if err := c.Start(ctx); err != nil {
+		t.Errorf("Start() error = %v, want nil for unsupported signal", err)
+	}
+}
+
+func TestExpandTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		ping Ping
+		want string
+	}{
+		{
+			name: "path and query escaping",
+			tmpl: "https://example.com/$SIGNAL/$MESSAGE?m=$MESSAGE",
+			ping: Ping{Signal: SignalLog, Body: []byte("a b/c")},
+			want: "https://example.com/log/a%20b%2Fc?m=a+b%2Fc",
+		},
+		{
+			name: "exit status",
+			tmpl: "https://example.com/$SIGNAL?code=$CODE&rid=$RUN_ID",
+			ping: Ping{Signal: SignalExitStatus(42), RunID: "rid"},
+			want: "https://example.com/exit-status?code=42&rid=rid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandTemplate(tt.tmpl, tt.ping); got != tt.want {
+				t.Errorf("expandTemplate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplateBackendInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		t    URLTemplates
+	}{
+		{name: "no success", t: URLTemplates{Fail: "https://example.com/fail"}},
+		{name: "missing host", t: URLTemplates{Success: "/success"}},
+		{name: "invalid fail", t: URLTemplates{Success: "https://example.com", Fail: "https://exa mple.com/%zz"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTemplateBackend(tt.t); err == nil {
+				t.Error("NewTemplateBackend() error = nil, want error")
+			}
+		})
+	}
+}
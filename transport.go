@@ -0,0 +1,84 @@
+package healthchecks
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"syscall"
+	"time"
+)
+
+// maxResponseSize limits the number of bytes read from a response body.
+// Responses of healthchecks.io are short status messages like "OK" or "OK (not found)".
+const maxResponseSize = 4 << 10
+
+// defaultTransport is shared by all checks using the default HTTP client,
+// so keep-alive connections are reused across checks pinging the same host.
+var defaultTransport = newTransport()
+
+func newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone() //nolint:errcheck
+	t.MaxIdleConns = 100
+	t.MaxIdleConnsPerHost = 16
+	// close idle connections before typical server-side keep-alive timeouts do,
+	// which avoids most resets of reused connections in the first place
+	t.IdleConnTimeout = 30 * time.Second
+	return t
+}
+
+// do sends req using client.
+//
+// If req was sent over a reused keep-alive connection which the server reset (e.g. because it closed the idle
+// connection concurrently), req is retried once on a fresh connection, unless retry is false.
+// Idle connections are dropped before, but only for the library's own client, since other clients may be shared.
+// attempts is the number of times req was sent.
+//
+// The server may have received req before resetting the connection, so a retried ping may arrive twice.
+func do(client *http.Client, req *http.Request, retry bool) (resp *http.Response, attempts int, err error) {
+	var reused bool
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			reused = info.Reused
+		},
+	}
+	resp, err = client.Do(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	if err == nil || !retry || !reused || !isConnReset(err) || req.Context().Err() != nil {
+		return resp, 1, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// body has been consumed and can't be replayed
+		return resp, 1, err
+	}
+
+	retried := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return nil, 1, err
+		}
+		retried.Body = body
+	}
+	if client.Transport == http.RoundTripper(defaultTransport) {
+		// other idle connections to the host are likely stale as well
+		defaultTransport.CloseIdleConnections()
+	}
+	resp, err = client.Do(retried)
+	return resp, 2, err
+}
+
+// isConnReset reports whether err indicates that the server closed or reset the connection.
+func isConnReset(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+// readResponse reads the (limited) body of resp and drains the remainder,
+// so the underlying connection can be reused.
+func readResponse(resp *http.Response) ([]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return b, err
+}
@@ -0,0 +1,143 @@
+package healthchecks
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// resettingServer is an HTTP/1.1 server which resets the connection on the n-th request instead of responding.
+func resettingServer(t *testing.T, resetOn int32) (url string, requests *atomic.Int32) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+
+	requests = new(atomic.Int32)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					req, err := http.ReadRequest(r)
+					if err != nil {
+						return
+					}
+					_, _ = io.Copy(io.Discard, req.Body)
+					if requests.Add(1) == resetOn {
+						return
+					}
+					if _, err := io.WriteString(conn, "HTTP/1.1 200 OK\r\nContent-Length: 2\r\n\r\nOK"); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return "http://" + l.Addr().String(), requests
+}
+
+func TestRequestRetriesResetConnection(t *testing.T) {
+	url, requests := resettingServer(t, 2)
+
+	opts, err := optsFromDefaults([]Option{
+		WithURL(url),
+		WithHTTPClient(&http.Client{Transport: newTransport()}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := request(context.Background(), opts, &healthchecksBackend{root: opts.RootURL, path: "6da9bc25-880d-4a73-a0e5-e833405e206f"}, SignalLog, sendOptions{body: strings.NewReader("foo")}); err != nil {
+			t.Fatalf("request() #%d error = %v", i, err)
+		}
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("server received %d requests, want 4", got)
+	}
+}
+
+// closeCountingTransport counts calls of CloseIdleConnections.
+type closeCountingTransport struct {
+	*http.Transport
+	closed atomic.Int32
+}
+
+func (t *closeCountingTransport) CloseIdleConnections() {
+	t.closed.Add(1)
+	t.Transport.CloseIdleConnections()
+}
+
+func TestRequestRetryKeepsCustomClientConnections(t *testing.T) {
+	url, requests := resettingServer(t, 2)
+	transport := &closeCountingTransport{Transport: newTransport()}
+
+	opts, err := optsFromDefaults([]Option{
+		WithURL(url),
+		WithHTTPClient(&http.Client{Transport: transport}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := request(context.Background(), opts, &healthchecksBackend{root: opts.RootURL, path: "6da9bc25-880d-4a73-a0e5-e833405e206f"}, SignalLog, sendOptions{body: strings.NewReader("foo")}); err != nil {
+			t.Fatalf("request() #%d error = %v", i, err)
+		}
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+	if got := transport.closed.Load(); got != 0 {
+		t.Errorf("closed idle connections of custom client %d times, want 0", got)
+	}
+}
+
+func BenchmarkRequest(b *testing.B) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "OK")
+	}))
+	defer server.Close()
+
+	benchmarks := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "keep-alive", opts: []Option{}},
+		{name: "close", opts: []Option{WithoutKeepAlive()}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			client := server.Client()
+			opts, err := optsFromDefaults(append(bm.opts, WithURL(server.URL), WithHTTPClient(client)))
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := request(context.Background(), opts, &healthchecksBackend{root: opts.RootURL, path: "6da9bc25-880d-4a73-a0e5-e833405e206f"}, SignalSuccess, sendOptions{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+			client.CloseIdleConnections()
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package healthchecks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxMessageInURL limits the length of messages sent as part of the URL.
+const maxMessageInURL = 1000
+
+// compile-time interface implementation check
+var _ Backend = (*UptimeKumaBackend)(nil)
+
+// UptimeKumaBackend sends signals to a push monitor of [Uptime Kuma].
+//
+// "success" and "exit-status" 0 report the monitor as up, "fail" and other exit codes as down, using the body as message.
+// Uptime Kuma has no equivalent of "start" and "log", so these signals are skipped.
+//
+// Use [NewUptimeKumaBackend] for obtaining a new instance.
+//
+// [Uptime Kuma]: https://github.com/louislam/uptime-kuma
+type UptimeKumaBackend struct {
+	pushURL *url.URL
+}
+
+// NewUptimeKumaBackend creates a new instance of [UptimeKumaBackend].
+//
+// The push URL is shown in the settings of the push monitor, in the format
+// http(s)://example.com/api/push/<token>. Query parameters like ?status=up&msg=OK are ignored.
+func NewUptimeKumaBackend(pushURL string) (*UptimeKumaBackend, error) {
+	parsed, err := parseURL(pushURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing push URL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, errors.New("invalid push URL: missing scheme or host")
+	}
+	segments := pathSegments(parsed.Path)
+	if len(segments) < 3 || segments[len(segments)-3] != "api" || segments[len(segments)-2] != "push" || segments[len(segments)-1] == "" {
+		return nil, errors.New("invalid push URL: expected path /api/push/<token>")
+	}
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return &UptimeKumaBackend{pushURL: parsed}, nil
+}
+
+// NewRequest implements [Backend].
+func (b *UptimeKumaBackend) NewRequest(ctx context.Context, p Ping) (*http.Request, error) {
+	var up bool
+	msg := string(p.Body)
+	switch p.Signal.kind {
+	case kindSuccess:
+		up = true
+	case kindFail:
+		// down
+	case kindExitStatus:
+		up = p.Signal.code == 0
+		if !up {
+			msg = strings.TrimSpace("exit status " + strconv.Itoa(p.Signal.code) + "\n" + msg)
+		}
+	default:
+		return nil, ErrUnsupportedSignal
+	}
+
+	status := "down"
+	if up {
+		status = "up"
+	}
+	if msg == "" {
+		msg = "OK"
+		if !up {
+			msg = "failed"
+		}
+	}
+	u := *b.pushURL
+	u.RawQuery = url.Values{
+		"status": {status},
+		"msg":    {truncate(msg, maxMessageInURL)},
+		"ping":   {""},
+	}.Encode()
+	return http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+}
+
+// CheckResponse implements [Backend].
+func (b *UptimeKumaBackend) CheckResponse(resp *http.Response, body []byte) error {
+	var result struct {
+		OK  bool   `json:"ok"`
+		Msg string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("HTTP response status %d: %s", resp.StatusCode, body)
+	}
+	if resp.StatusCode != http.StatusOK || !result.OK {
+		return fmt.Errorf("HTTP response status %d: %s", resp.StatusCode, result.Msg)
+	}
+	return nil
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,104 @@
+package healthchecks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestUptimeKumaBackend(t *testing.T) {
+	var gotQueries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/push/abc123" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"ok": false, "msg": "Monitor not found or not active."}`)
+			return
+		}
+		gotQueries = append(gotQueries, r.URL.Query())
+		_, _ = io.WriteString(w, `{"ok": true}`)
+	}))
+	defer srv.Close()
+
+	b, err := NewUptimeKumaBackend(srv.URL + "/api/push/abc123?status=up&msg=OK&ping=")
+	if err != nil {
+		t.Fatalf("NewUptimeKumaBackend() error = %v", err)
+	}
+	c, err := NewCheck(b)
+	if err != nil {
+		t.Fatalf("NewCheck() error = %v", err)
+	}
+	ctx := context.Background()
+	for _, send := range []func() error{
+		func() error { return c.Start(ctx) },
+		func() error { return c.Log(ctx, "ignored") },
+		func() error { return c.Success(ctx) },
+		func() error { return c.Send(ctx, SignalFail, WithBody("disk full")) },
+		func() error { return c.ExitStatus(ctx, 0) },
+		func() error { return c.ExitStatus(ctx, 2) },
+	} {
+		if err := send(); err != nil {
+			t.Errorf("signal error = %v", err)
+		}
+	}
+
+	want := []struct{ status, msg string }{
+		{"up", "OK"},
+		{"down", "disk full"},
+		{"up", "OK"},
+		{"down", "exit status 2"},
+	}
+	if len(gotQueries) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(gotQueries), len(want))
+	}
+	for i, w := range want {
+		if got := gotQueries[i]; got.Get("status") != w.status || got.Get("msg") != w.msg {
+			t.Errorf("request %d = status=%s msg=%s, want status=%s msg=%s", i, got.Get("status"), got.Get("msg"), w.status, w.msg)
+		}
+	}
+
+	b, err = NewUptimeKumaBackend(srv.URL + "/api/push/unknown")
+	if err != nil {
+		t.Fatalf("NewUptimeKumaBackend() error = %v", err)
+	}
+	c, err = NewCheck(b)
+	if err != nil {
+		t.Fatalf("NewCheck() error = %v", err)
+	}
+	if err := c.Success(ctx); err == nil || !strings.Contains(err.Error(), "Monitor not found") {
+		t.Errorf("Success() for unknown monitor error = %v, want error", err)
+	}
+}
+
+func TestNewUptimeKumaBackendInvalid(t *testing.T) {
+	for _, u := range []string{
+		"kuma.example.com/api/push/abc123",
+		"https://kuma.example.com/abc123",
+		"https://kuma.example.com/api/push/",
+		"https://kuma.example.com/api/status/abc123",
+	} {
+		if _, err := NewUptimeKumaBackend(u); err == nil {
+			t.Errorf("NewUptimeKumaBackend(%s) error = nil, want error", u)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{s: "abc", n: 5, want: "abc"},
+		{s: "abcdef", n: 3, want: "abc"},
+		{s: "aäb", n: 2, want: "a"},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.s, tt.n); got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package healthchecks
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// parseURL parses u like [url.Parse], but omits the URL itself from errors since it might contain secrets.
+func parseURL(u string) (*url.URL, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return nil, urlErr.Err
+		}
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// urlOptions converts the supported query parameters of a ping URL to options.
+func urlOptions(query url.Values) ([]Option, error) {
+	var opts []Option
+	for key := range query {
+		value := query.Get(key)
+		switch key {
+		case "create":
+			create, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid URL parameter create=%s: %w", value, err)
+			}
+			if create {
+				opts = append(opts, WithAutoCreate())
+			}
+		case "rid":
+			opts = append(opts, WithRunID(value))
+		default:
+			return nil, fmt.Errorf("unsupported URL parameter '%s'", key)
+		}
+	}
+	return opts, nil
+}
+
+func pathSegments(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func rootURL(scheme, host string, prefix []string) *url.URL {
+	root := &url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	if len(prefix) > 0 {
+		root.Path = "/" + strings.Join(prefix, "/")
+	}
+	return root
+}
+
+// isSignalSuffix reports whether s is the last path segment of a signal other than "success",
+// i.e. "start", "fail", "log" or an exit status.
+func isSignalSuffix(s string) bool {
+	switch s {
+	case "start", "fail", "log":
+		return true
+	}
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
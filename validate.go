@@ -0,0 +1,91 @@
+package healthchecks
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	// ErrInvalidUUID is wrapped by a [*ValidationError] if a check UUID is malformed.
+	ErrInvalidUUID = errors.New("invalid UUID")
+	// ErrInvalidSlug is wrapped by a [*ValidationError] if a check slug is malformed.
+	ErrInvalidSlug = errors.New("invalid slug")
+	// ErrInvalidPingKey is wrapped by a [*ValidationError] if a project ping key is malformed.
+	ErrInvalidPingKey = errors.New("invalid ping key")
+)
+
+var (
+	uuidPattern    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	slugPattern    = regexp.MustCompile(`^[a-z0-9_-]+$`)
+	pingKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{22}$`)
+)
+
+// ValidationError is returned when a UUID, slug or ping key does not match the format used by healthchecks.io.
+//
+// Use [errors.Is] with [ErrInvalidUUID], [ErrInvalidSlug] or [ErrInvalidPingKey] to determine which value was rejected.
+// Validation can be disabled using [WithoutValidation].
+type ValidationError struct {
+	// Kind is one of [ErrInvalidUUID], [ErrInvalidSlug] or [ErrInvalidPingKey].
+	Kind error
+	// Value is the rejected value. It is empty for ping keys, which are secret.
+	// UUIDs are secret as well, so they are redacted in the message returned by Error.
+	Value string
+	// Reason describes the expected format.
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	switch {
+	case e.Value == "":
+		return fmt.Sprintf("%v: %s", e.Kind, e.Reason)
+	case errors.Is(e.Kind, ErrInvalidUUID):
+		return fmt.Sprintf("%v '%s': %s", e.Kind, redacted, e.Reason)
+	default:
+		return fmt.Sprintf("%v '%s': %s", e.Kind, e.Value, e.Reason)
+	}
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Kind
+}
+
+func isUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
+
+func isPingKey(s string) bool {
+	return pingKeyPattern.MatchString(s)
+}
+
+func validateUUID(uuid string) error {
+	if !isUUID(uuid) {
+		return &ValidationError{
+			Kind:   ErrInvalidUUID,
+			Value:  uuid,
+			Reason: "needs to be in the format xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx",
+		}
+	}
+	return nil
+}
+
+func validateSlug(slug string) error {
+	if !slugPattern.MatchString(slug) {
+		return &ValidationError{
+			Kind:   ErrInvalidSlug,
+			Value:  slug,
+			Reason: "may only contain lowercase letters, digits, hyphens and underscores",
+		}
+	}
+	return nil
+}
+
+func validatePingKey(pingKey string) error {
+	if !isPingKey(pingKey) {
+		return &ValidationError{
+			Kind:   ErrInvalidPingKey,
+			Reason: "needs to be 22 characters of letters, digits, hyphens and underscores",
+		}
+	}
+	return nil
+}
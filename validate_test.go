@@ -0,0 +1,47 @@
+package healthchecks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) error
+		value    string
+		wantErr  error
+	}{
+		{name: "uuid valid", validate: validateUUID, value: "6da9bc25-880d-4a73-a0e5-e833405e206f", wantErr: nil},
+		{name: "uuid uppercase", validate: validateUUID, value: "6DA9BC25-880D-4A73-A0E5-E833405E206F", wantErr: nil},
+		{name: "uuid too short", validate: validateUUID, value: "6da9bc25-880d-4a73-a0e5-e833405e206", wantErr: ErrInvalidUUID},
+		{name: "uuid no hyphens", validate: validateUUID, value: "6da9bc25880d4a73a0e5e833405e206f", wantErr: ErrInvalidUUID},
+		{name: "uuid non-hex", validate: validateUUID, value: "6da9bc25-880d-4a73-a0e5-e833405e206g", wantErr: ErrInvalidUUID},
+		{name: "slug valid", validate: validateSlug, value: "db-backup_2", wantErr: nil},
+		{name: "slug uppercase", validate: validateSlug, value: "DB-backup", wantErr: ErrInvalidSlug},
+		{name: "slug whitespace", validate: validateSlug, value: "db backup", wantErr: ErrInvalidSlug},
+		{name: "slug empty", validate: validateSlug, value: "", wantErr: ErrInvalidSlug},
+		{name: "ping key valid", validate: validatePingKey, value: "rk9bbOJREu6nOWeHGjlnDQ", wantErr: nil},
+		{name: "ping key too short", validate: validatePingKey, value: "rk9bbOJREu6nOWeHGjlnD", wantErr: ErrInvalidPingKey},
+		{name: "ping key invalid char", validate: validatePingKey, value: "rk9bbOJREu6nOWeHGjln/Q", wantErr: ErrInvalidPingKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate(tt.value)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("validate(%s) error = %v, want nil", tt.value, err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || !errors.Is(err, tt.wantErr) {
+				t.Errorf("validate(%s) error = %v, want %v", tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr != ErrInvalidSlug && strings.Contains(err.Error(), tt.value) {
+				t.Errorf("validate(%s) error = %v, contains secret", tt.value, err)
+			}
+		})
+	}
+}
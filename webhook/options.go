@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// defaultDedupWindow is the default duration repeated deliveries are ignored for.
+const defaultDedupWindow = time.Hour
+
+type options struct {
+	Template     string
+	Secret       string
+	SecretParam  string
+	SecretHeader string
+	DedupWindow  time.Duration
+	Logger       *slog.Logger
+}
+
+func defaultOptions() *options {
+	return &options{
+		Template:    DefaultTemplate,
+		DedupWindow: defaultDedupWindow,
+		Logger:      slog.Default(),
+	}
+}
+
+// Option applies a configuration option to a [Handler].
+type Option interface {
+	apply(opts *options) error
+}
+
+type templateOption string
+
+var _ Option = templateOption("")
+
+func (t templateOption) apply(opts *options) error {
+	opts.Template = string(t)
+	return nil
+}
+
+// WithTemplate sets the request body template configured in the webhook integration of healthchecks.io.
+//
+// It needs to be a JSON object whose values are placeholders, e.g. {"id": "$CODE", "state": "$STATUS", "time": "$NOW"}.
+// Objects may be nested. $CODE (or $JSON) and $STATUS are required.
+// Prefer $JSON over $NAME and $TAGS, which healthchecks.io doesn't escape.
+// Without $NOW, deliveries aren't deduplicated (see [WithDeduplication]).
+// Defaults to [DefaultTemplate].
+func WithTemplate(tmpl string) Option {
+	return templateOption(tmpl)
+}
+
+type secretOption struct {
+	param  string
+	header string
+	secret string
+}
+
+var _ Option = secretOption{}
+
+func (s secretOption) apply(opts *options) error {
+	if s.secret == "" {
+		return errors.New("secret must not be empty")
+	}
+	if s.param == "" && s.header == "" {
+		return errors.New("secret parameter or header name must not be empty")
+	}
+	opts.Secret = s.secret
+	opts.SecretParam = s.param
+	opts.SecretHeader = s.header
+	return nil
+}
+
+// WithSecretParam requires the query parameter param of the webhook URL to equal secret,
+// e.g. https://example.com/hook?token=<secret>.
+func WithSecretParam(param, secret string) Option {
+	return secretOption{param: param, secret: secret}
+}
+
+// WithSecretHeader requires the request header to equal secret.
+//
+// Custom headers can be configured in the webhook integration of healthchecks.io.
+func WithSecretHeader(header, secret string) Option {
+	return secretOption{header: header, secret: secret}
+}
+
+type dedupOption time.Duration
+
+var _ Option = dedupOption(0)
+
+func (d dedupOption) apply(opts *options) error {
+	if d < 0 {
+		return fmt.Errorf("deduplication window is %d, needs to be >= 0", d)
+	}
+	opts.DedupWindow = time.Duration(d)
+	return nil
+}
+
+// WithDeduplication ignores deliveries of the same event received within window, 0 to disable.
+// Events are identified by the UUID and status of the check and the time of the notification.
+//
+// Defaults to one hour. Deduplication is disabled if the template doesn't contain $NOW, since repeated
+// deliveries couldn't be told from new events then. Deliveries are only remembered once all handlers succeeded, so failed ones can be redelivered.
+// A delivery repeated while the first one is still being handled is dispatched again.
+func WithDeduplication(window time.Duration) Option {
+	return dedupOption(window)
+}
+
+type loggerOption struct {
+	logger *slog.Logger
+}
+
+var _ Option = loggerOption{}
+
+func (l loggerOption) apply(opts *options) error {
+	if l.logger == nil {
+		return errors.New("logger must not be nil")
+	}
+	opts.Logger = l.logger
+	return nil
+}
+
+// WithLogger sets the logger for failed handlers and ignored deliveries.
+//
+// Defaults to [slog.Default].
+func WithLogger(l *slog.Logger) Option {
+	return loggerOption{logger: l}
+}
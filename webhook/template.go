@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Placeholders supported in templates, as substituted by healthchecks.io.
+//
+// healthchecks.io inserts the values of $NAME and $TAGS verbatim, so quotes or line breaks in the name or tags
+// of a check result in invalid JSON. [DefaultTemplate] therefore takes them from $JSON, which is encoded properly.
+const (
+	PlaceholderName   = "$NAME"
+	PlaceholderSlug   = "$SLUG"
+	PlaceholderUUID   = "$CODE"
+	PlaceholderStatus = "$STATUS"
+	PlaceholderNow    = "$NOW"
+	PlaceholderTags   = "$TAGS"
+	// PlaceholderJSON is substituted with a JSON object describing the check, including the time of its last ping.
+	// Unlike the other placeholders, it must not be quoted in the template.
+	PlaceholderJSON = "$JSON"
+)
+
+// DefaultTemplate is the default request body to configure in the webhook integration of healthchecks.io.
+//
+// Use the method POST and the content type application/json.
+// It only contains placeholders whose values need no escaping, name and tags are taken from $JSON.
+const DefaultTemplate = `{"uuid": "$CODE", "slug": "$SLUG", "status": "$STATUS", "now": "$NOW", "check": $JSON}`
+
+var placeholders = map[string]bool{
+	PlaceholderName:   true,
+	PlaceholderSlug:   true,
+	PlaceholderUUID:   true,
+	PlaceholderStatus: true,
+	PlaceholderNow:    true,
+	PlaceholderTags:   true,
+	PlaceholderJSON:   true,
+}
+
+// template maps placeholders to their location in the JSON payload.
+type template struct {
+	paths map[string][]string
+}
+
+// parseTemplate parses a JSON template whose values are placeholders, e.g. {"check": {"name": "$NAME"}, "state": "$STATUS"}.
+//
+// Values which aren't exactly a placeholder are ignored.
+func parseTemplate(tmpl string) (*template, error) {
+	// $JSON is substituted unquoted, which isn't valid JSON in the template itself
+	tmpl = strings.ReplaceAll(tmpl, `"`+PlaceholderJSON+`"`, PlaceholderJSON)
+	tmpl = strings.ReplaceAll(tmpl, PlaceholderJSON, `"`+PlaceholderJSON+`"`)
+
+	var root map[string]any
+	if err := json.Unmarshal([]byte(tmpl), &root); err != nil {
+		return nil, fmt.Errorf("template is not a JSON object: %w", err)
+	}
+	t := &template{paths: map[string][]string{}}
+	if err := t.collect(root, nil); err != nil {
+		return nil, err
+	}
+	if _, ok := t.paths[PlaceholderUUID]; !ok {
+		if _, ok := t.paths[PlaceholderJSON]; !ok {
+			return nil, fmt.Errorf("template needs to contain %s or %s", PlaceholderUUID, PlaceholderJSON)
+		}
+	}
+	if _, ok := t.paths[PlaceholderStatus]; !ok {
+		return nil, fmt.Errorf("template needs to contain %s", PlaceholderStatus)
+	}
+	return t, nil
+}
+
+func (t *template) collect(obj map[string]any, path []string) error {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := append(append([]string(nil), path...), k)
+		switch v := obj[k].(type) {
+		case map[string]any:
+			if err := t.collect(v, p); err != nil {
+				return err
+			}
+		case string:
+			if !placeholders[v] {
+				continue
+			}
+			if _, ok := t.paths[v]; ok {
+				return fmt.Errorf("placeholder %s used more than once", v)
+			}
+			t.paths[v] = p
+		}
+	}
+	return nil
+}
+
+var errMissingValue = errors.New("missing value")
+
+// lookup returns the raw JSON value of placeholder in payload, or nil if the template doesn't contain it.
+func (t *template) lookup(payload map[string]json.RawMessage, placeholder string) (json.RawMessage, error) {
+	path, ok := t.paths[placeholder]
+	if !ok {
+		return nil, nil
+	}
+	obj := payload
+	for i, key := range path {
+		raw, ok := obj[key]
+		if !ok {
+			return nil, fmt.Errorf("%w at '%s'", errMissingValue, strings.Join(path[:i+1], "."))
+		}
+		if i == len(path)-1 {
+			return raw, nil
+		}
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, fmt.Errorf("'%s' is not an object", strings.Join(path[:i+1], "."))
+		}
+	}
+	return nil, nil
+}
+
+// has reports whether the template contains placeholder.
+func (t *template) has(placeholder string) bool {
+	_, ok := t.paths[placeholder]
+	return ok
+}
+
+// lookupString returns the string value of placeholder in payload, or an empty string if the template doesn't contain it.
+func (t *template) lookupString(payload map[string]json.RawMessage, placeholder string) (string, error) {
+	raw, err := t.lookup(payload, placeholder)
+	if err != nil || raw == nil {
+		return "", err
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", fmt.Errorf("value of %s is not a string", placeholder)
+	}
+	return s, nil
+}
@@ -0,0 +1,339 @@
+// Package webhook receives notifications sent by the webhook integration of healthchecks.io.
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// maxPayloadSize is the maximum size of a webhook request body.
+const maxPayloadSize = 64 << 10
+
+// Status is the status of a check reported by a webhook.
+type Status string
+
+const (
+	// StatusUp is reported when a check recovers.
+	StatusUp Status = "up"
+	// StatusDown is reported when a check goes down.
+	StatusDown Status = "down"
+)
+
+// Event is a notification about a check changing its status.
+//
+// Fields whose placeholder is missing in the template are empty.
+type Event struct {
+	// UUID identifies the check.
+	UUID string
+	// Name is the name of the check.
+	Name string
+	// Slug is the slug of the check.
+	Slug string
+	// Status is the new status of the check.
+	Status Status
+	// Time is the time the notification was sent.
+	Time time.Time
+	// Tags are the tags of the check.
+	Tags []string
+	// LastPing is the time of the last ping the check received.
+	// It is only available if the template contains $JSON.
+	LastPing time.Time
+}
+
+// logName identifies the check in logs by its slug or name, since its UUID is a secret.
+func (e *Event) logName() string {
+	switch {
+	case e.Slug != "":
+		return e.Slug
+	case e.Name != "":
+		return e.Name
+	default:
+		return "****"
+	}
+}
+
+// HandlerFunc reacts to an [Event].
+type HandlerFunc func(ctx context.Context, e Event) error
+
+// checkJSON is the check as substituted for $JSON.
+type checkJSON struct {
+	UUID     string    `json:"uuid"`
+	Name     string    `json:"name"`
+	Slug     string    `json:"slug"`
+	Tags     string    `json:"tags"`
+	Status   string    `json:"status"`
+	LastPing time.Time `json:"last_ping"`
+}
+
+// Handler is an [http.Handler] receiving webhook notifications and dispatching them as [Event] to registered handlers.
+//
+// A function registered several times, e.g. for a check and one of its tags, is called once per event.
+//
+// It responds with status 204 if all handlers succeeded, 500 if any failed, 400 for invalid payloads
+// and 401 if the shared secret doesn't match.
+//
+// Use [NewHandler] for obtaining a new instance.
+type Handler struct {
+	opts *options
+	tmpl *template
+
+	mu      sync.RWMutex
+	byCheck map[string][]HandlerFunc
+	byTag   map[string][]HandlerFunc
+	all     []HandlerFunc
+
+	seenMu sync.Mutex
+	seen   map[string]time.Time // delivery key -> time handled
+}
+
+// compile-time interface implementation check
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler creates a new instance of [Handler].
+//
+// By default, payloads are expected to match [DefaultTemplate], see [WithTemplate].
+func NewHandler(opts ...Option) (*Handler, error) {
+	options := defaultOptions()
+	for _, o := range opts {
+		if err := o.apply(options); err != nil {
+			return nil, fmt.Errorf("applying option: %w", err)
+		}
+	}
+	tmpl, err := parseTemplate(options.Template)
+	if err != nil {
+		return nil, err
+	}
+	if !tmpl.has(PlaceholderNow) {
+		// without the time, repeated deliveries can't be told from new events of the same check
+		options.DedupWindow = 0
+	}
+	return &Handler{
+		opts:    options,
+		tmpl:    tmpl,
+		byCheck: map[string][]HandlerFunc{},
+		byTag:   map[string][]HandlerFunc{},
+		seen:    map[string]time.Time{},
+	}, nil
+}
+
+// HandleCheck registers fn for events of the check identified by its UUID or slug.
+func (h *Handler) HandleCheck(id string, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.byCheck[id] = append(h.byCheck[id], fn)
+}
+
+// HandleTag registers fn for events of checks with tag.
+func (h *Handler) HandleTag(tag string, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.byTag[tag] = append(h.byTag[tag], fn)
+}
+
+// HandleAll registers fn for all events.
+func (h *Handler) HandleAll(fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.all = append(h.all, fn)
+}
+
+// ServeHTTP implements [http.Handler].
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		w.Header().Set("Allow", "POST, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(r) {
+		http.Error(w, "invalid secret", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, "reading body", http.StatusBadRequest)
+		return
+	}
+	e, err := h.parse(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	key := e.UUID + "/" + string(e.Status) + "/" + e.Time.UTC().Format(time.RFC3339Nano)
+	if h.delivered(key) {
+		h.opts.Logger.Debug("ignoring repeated webhook delivery", "check", e.logName(), "status", e.Status)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := h.dispatch(r.Context(), e); err != nil {
+		// not remembered, so it can be redelivered
+		h.opts.Logger.Error("handling webhook event", "check", e.logName(), "status", e.Status, "error", err)
+		http.Error(w, "handling event failed", http.StatusInternalServerError)
+		return
+	}
+	h.remember(key)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorized reports whether r carries the shared secret, if one is configured.
+func (h *Handler) authorized(r *http.Request) bool {
+	if h.opts.Secret == "" {
+		return true
+	}
+	var got string
+	if h.opts.SecretHeader != "" {
+		got = r.Header.Get(h.opts.SecretHeader)
+	} else {
+		got = r.URL.Query().Get(h.opts.SecretParam)
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(h.opts.Secret)) == 1
+}
+
+// parse extracts the event from a payload matching the template.
+func (h *Handler) parse(body []byte) (Event, error) {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return Event{}, fmt.Errorf("payload is not a JSON object: %w", err)
+	}
+
+	var e Event
+	var check checkJSON
+	if raw, err := h.tmpl.lookup(payload, PlaceholderJSON); err != nil {
+		return Event{}, err
+	} else if raw != nil {
+		if err := json.Unmarshal(raw, &check); err != nil {
+			return Event{}, fmt.Errorf("invalid value of %s: %w", PlaceholderJSON, err)
+		}
+		e.LastPing = check.LastPing
+	}
+
+	var status, now, tags string
+	for placeholder, dst := range map[string]*string{
+		PlaceholderUUID:   &e.UUID,
+		PlaceholderName:   &e.Name,
+		PlaceholderSlug:   &e.Slug,
+		PlaceholderStatus: &status,
+		PlaceholderNow:    &now,
+		PlaceholderTags:   &tags,
+	} {
+		v, err := h.tmpl.lookupString(payload, placeholder)
+		if err != nil {
+			return Event{}, err
+		}
+		*dst = v
+	}
+
+	// fall back to $JSON
+	e.UUID = firstNonEmpty(e.UUID, check.UUID)
+	e.Name = firstNonEmpty(e.Name, check.Name)
+	e.Slug = firstNonEmpty(e.Slug, check.Slug)
+	tags = firstNonEmpty(tags, check.Tags)
+
+	if e.UUID == "" {
+		return Event{}, errors.New("missing check UUID")
+	}
+	switch Status(status) {
+	case StatusUp, StatusDown:
+		e.Status = Status(status)
+	default:
+		return Event{}, fmt.Errorf("invalid status '%s'", status)
+	}
+	if now != "" {
+		t, err := time.Parse(time.RFC3339, now)
+		if err != nil {
+			return Event{}, fmt.Errorf("invalid time '%s'", now)
+		}
+		e.Time = t
+	}
+	e.Tags = strings.Fields(tags)
+	return e, nil
+}
+
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
+
+// delivered reports whether a delivery has been handled successfully within the deduplication window.
+func (h *Handler) delivered(key string) bool {
+	if h.opts.DedupWindow <= 0 {
+		return false
+	}
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+
+	now := time.Now()
+	for k, t := range h.seen {
+		if now.Sub(t) >= h.opts.DedupWindow {
+			delete(h.seen, k)
+		}
+	}
+	_, ok := h.seen[key]
+	return ok
+}
+
+// remember records a successfully handled delivery.
+func (h *Handler) remember(key string) {
+	if h.opts.DedupWindow <= 0 {
+		return
+	}
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+	h.seen[key] = time.Now()
+}
+
+// dispatch calls all handlers registered for e once, returning all their errors.
+func (h *Handler) dispatch(ctx context.Context, e Event) error {
+	h.mu.RLock()
+	fns := append([]HandlerFunc(nil), h.all...)
+	fns = append(fns, h.byCheck[e.UUID]...)
+	if e.Slug != "" && e.Slug != e.UUID {
+		fns = append(fns, h.byCheck[e.Slug]...)
+	}
+	for _, tag := range e.Tags {
+		fns = append(fns, h.byTag[tag]...)
+	}
+	h.mu.RUnlock()
+
+	var errs []error
+	called := make(map[unsafe.Pointer]bool, len(fns))
+	for _, fn := range fns {
+		id := funcID(fn)
+		if called[id] {
+			continue
+		}
+		called[id] = true
+		if err := call(ctx, fn, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// funcID identifies the function value fn. A function or closure registered several times has the same ID,
+// unlike distinct closures created by the same function literal.
+func funcID(fn HandlerFunc) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&fn))
+}
+
+// call calls fn, turning a panic into an error.
+func call(ctx context.Context, fn HandlerFunc, e Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx, e)
+}
@@ -0,0 +1,384 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testUUID = "6da9bc25-880d-4a73-a0e5-e833405e206f"
+
+const testPayload = `{"uuid": "` + testUUID + `", "slug": "backup", "status": "down", "now": "2024-01-02T03:04:05+00:00",
+	"check": {"name": "Backup", "slug": "backup", "tags": "prod db", "status": "down", "last_ping": "2024-01-01T03:00:00+00:00"}}`
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func newTestHandler(t *testing.T, opts ...Option) *Handler {
+	t.Helper()
+	h, err := NewHandler(append([]Option{WithLogger(testLogger)}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+	return h
+}
+
+func deliver(h http.Handler, target string, body string, header http.Header) int {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+// eventRecorder records all events passed to its handler.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) handle(_ context.Context, e Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+	return nil
+}
+
+func TestHandlerParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		payload string
+		want    Event
+	}{
+		{
+			name:    "default template",
+			payload: testPayload,
+			want: Event{
+				UUID:     testUUID,
+				Name:     "Backup",
+				Slug:     "backup",
+				Status:   StatusDown,
+				Time:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Tags:     []string{"prod", "db"},
+				LastPing: time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "name with quotes and line breaks",
+			payload: `{"uuid": "` + testUUID + `", "slug": "backup", "status": "up", "now": "2024-01-02T03:04:05+00:00",
+				"check": {"name": "Backup \"db\"\nnightly", "slug": "backup", "tags": "", "status": "up", "last_ping": null}}`,
+			want: Event{
+				UUID:   testUUID,
+				Name:   "Backup \"db\"\nnightly",
+				Slug:   "backup",
+				Status: StatusUp,
+				Time:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Tags:   []string{},
+			},
+		},
+		{
+			name:    "custom nested template",
+			opts:    []Option{WithTemplate(`{"check": {"id": "$CODE", "title": "$NAME"}, "state": "$STATUS", "source": "healthchecks"}`)},
+			payload: `{"check": {"id": "` + testUUID + `", "title": "Backup"}, "state": "up", "source": "healthchecks"}`,
+			want: Event{
+				UUID:   testUUID,
+				Name:   "Backup",
+				Status: StatusUp,
+				Tags:   []string{},
+			},
+		},
+		{
+			name:    "only $JSON",
+			opts:    []Option{WithTemplate(`{"status": "$STATUS", "check": $JSON}`)},
+			payload: `{"status": "up", "check": {"uuid": "` + testUUID + `", "name": "Backup", "tags": "prod"}}`,
+			want: Event{
+				UUID:   testUUID,
+				Name:   "Backup",
+				Status: StatusUp,
+				Tags:   []string{"prod"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(t, tt.opts...)
+			rec := &eventRecorder{}
+			h.HandleAll(rec.handle)
+
+			if code := deliver(h, "/hook", tt.payload, nil); code != http.StatusNoContent {
+				t.Fatalf("status = %d, want %d", code, http.StatusNoContent)
+			}
+			if len(rec.events) != 1 {
+				t.Fatalf("got %d events, want 1", len(rec.events))
+			}
+			got := rec.events[0]
+			if !got.Time.Equal(tt.want.Time) || !got.LastPing.Equal(tt.want.LastPing) {
+				t.Errorf("times = %v, %v, want %v, %v", got.Time, got.LastPing, tt.want.Time, tt.want.LastPing)
+			}
+			got.Time, got.LastPing = tt.want.Time, tt.want.LastPing
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("event = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerInvalidPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		payload string
+		want    int
+	}{
+		{name: "wrong method", method: http.MethodGet, payload: testPayload, want: http.StatusMethodNotAllowed},
+		{name: "not JSON", method: http.MethodPost, payload: "down", want: http.StatusBadRequest},
+		{name: "invalid status", method: http.MethodPost, payload: `{"uuid": "` + testUUID + `", "status": "sideways"}`, want: http.StatusBadRequest},
+		{name: "missing uuid", method: http.MethodPost, payload: `{"status": "up"}`, want: http.StatusBadRequest},
+		{name: "invalid time", method: http.MethodPost, payload: `{"uuid": "` + testUUID + `", "status": "up", "now": "yesterday"}`, want: http.StatusBadRequest},
+		{name: "nested value not an object", method: http.MethodPost, payload: `{"uuid": "` + testUUID + `", "status": "up", "check": "foo"}`, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(t)
+			req := httptest.NewRequest(tt.method, "/hook", strings.NewReader(tt.payload))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerSecret(t *testing.T) {
+	tests := []struct {
+		name   string
+		opt    Option
+		target string
+		header http.Header
+		want   int
+	}{
+		{name: "param", opt: WithSecretParam("token", "s3cret"), target: "/hook?token=s3cret", want: http.StatusNoContent},
+		{name: "wrong param", opt: WithSecretParam("token", "s3cret"), target: "/hook?token=guess", want: http.StatusUnauthorized},
+		{name: "missing param", opt: WithSecretParam("token", "s3cret"), target: "/hook", want: http.StatusUnauthorized},
+		{name: "header", opt: WithSecretHeader("X-Secret", "s3cret"), target: "/hook", header: http.Header{"X-Secret": {"s3cret"}}, want: http.StatusNoContent},
+		{name: "header in param", opt: WithSecretHeader("X-Secret", "s3cret"), target: "/hook?X-Secret=s3cret", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(t, tt.opt)
+			if code := deliver(h, tt.target, testPayload, tt.header); code != tt.want {
+				t.Errorf("status = %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerDispatch(t *testing.T) {
+	h := newTestHandler(t)
+	var mu sync.Mutex
+	var called []string
+	register := func(name string) HandlerFunc {
+		return func(context.Context, Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			called = append(called, name)
+			return nil
+		}
+	}
+	h.HandleAll(register("all"))
+	h.HandleCheck(testUUID, register("uuid"))
+	h.HandleCheck("backup", register("slug"))
+	h.HandleCheck("other", register("other check"))
+	h.HandleTag("db", register("tag"))
+	h.HandleTag("staging", register("other tag"))
+
+	if code := deliver(h, "/hook", testPayload, nil); code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", code, http.StatusNoContent)
+	}
+	if want := []string{"all", "uuid", "slug", "tag"}; !reflect.DeepEqual(called, want) {
+		t.Errorf("called = %v, want %v", called, want)
+	}
+}
+
+func TestHandlerDeduplication(t *testing.T) {
+	h := newTestHandler(t)
+	calls := 0
+	fail := true
+	h.HandleAll(func(context.Context, Event) error {
+		calls++
+		if fail {
+			return errors.New("ticket system down")
+		}
+		return nil
+	})
+
+	// failed deliveries are not remembered
+	if code := deliver(h, "/hook", testPayload, nil); code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", code, http.StatusInternalServerError)
+	}
+	fail = false
+	for i := 0; i < 2; i++ {
+		if code := deliver(h, "/hook", testPayload, nil); code != http.StatusNoContent {
+			t.Fatalf("status = %d, want %d", code, http.StatusNoContent)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+
+	// a different delivery for the same check
+	if code := deliver(h, "/hook", strings.Replace(testPayload, `"down"`, `"up"`, 1), nil); code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", code, http.StatusNoContent)
+	}
+	if calls != 3 {
+		t.Errorf("handler called %d times, want 3", calls)
+	}
+}
+
+func TestHandlerDeduplicationInFlight(t *testing.T) {
+	h := newTestHandler(t)
+	calls := 0
+	var redelivered int
+	h.HandleAll(func(context.Context, Event) error {
+		calls++
+		if calls == 1 {
+			// healthchecks.io retries while the first delivery is still being handled, which then fails
+			redelivered = deliver(h, "/hook", testPayload, nil)
+			return errors.New("ticket system down")
+		}
+		return nil
+	})
+
+	if code := deliver(h, "/hook", testPayload, nil); code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", code, http.StatusInternalServerError)
+	}
+	if redelivered != http.StatusNoContent || calls != 2 {
+		t.Errorf("redelivery status = %d with %d calls, want %d with 2 calls", redelivered, calls, http.StatusNoContent)
+	}
+	// remembered once handled successfully
+	if code := deliver(h, "/hook", testPayload, nil); code != http.StatusNoContent || calls != 2 {
+		t.Errorf("status = %d with %d calls, want %d with 2 calls", code, calls, http.StatusNoContent)
+	}
+}
+
+func TestHandlerDispatchOnce(t *testing.T) {
+	h := newTestHandler(t)
+	rec := &eventRecorder{}
+	fn := rec.handle
+	h.HandleAll(fn)
+	h.HandleCheck(testUUID, fn)
+	h.HandleCheck("backup", fn)
+	h.HandleTag("db", fn)
+
+	if code := deliver(h, "/hook", testPayload, nil); code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", code, http.StatusNoContent)
+	}
+	if len(rec.events) != 1 {
+		t.Errorf("handler called %d times, want 1", len(rec.events))
+	}
+}
+
+func TestHandlerDeduplicationKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		payloads  []string
+		wantCalls int
+	}{
+		{
+			name: "same event with different payload",
+			payloads: []string{
+				testPayload,
+				strings.Replace(testPayload, `"prod db"`, `"prod"`, 1),
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "same check and status at a different time",
+			payloads: []string{
+				testPayload,
+				strings.Replace(testPayload, "2024-01-02T03:04:05+00:00", "2024-01-02T03:34:05+00:00", 1),
+			},
+			wantCalls: 2,
+		},
+		{
+			name:      "template without $NOW",
+			opts:      []Option{WithTemplate(`{"id": "$CODE", "state": "$STATUS"}`)},
+			payloads:  []string{`{"id": "` + testUUID + `", "state": "down"}`, `{"id": "` + testUUID + `", "state": "down"}`},
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(t, tt.opts...)
+			rec := &eventRecorder{}
+			h.HandleAll(rec.handle)
+			for _, p := range tt.payloads {
+				if code := deliver(h, "/hook", p, nil); code != http.StatusNoContent {
+					t.Fatalf("status = %d, want %d", code, http.StatusNoContent)
+				}
+			}
+			if len(rec.events) != tt.wantCalls {
+				t.Errorf("handler called %d times, want %d", len(rec.events), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestHandlerLogsRedacted(t *testing.T) {
+	var buf bytes.Buffer
+	h := newTestHandler(t, WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	h.HandleAll(func(context.Context, Event) error {
+		return errors.New("ticket system down")
+	})
+	_ = deliver(h, "/hook", testPayload, nil)
+
+	if got := buf.String(); strings.Contains(got, testUUID) || !strings.Contains(got, "check=backup") {
+		t.Errorf("log = %s, want check slug without UUID", got)
+	}
+}
+
+func TestHandlerPanic(t *testing.T) {
+	h := newTestHandler(t)
+	h.HandleAll(func(context.Context, Event) error {
+		panic("oops")
+	})
+	if code := deliver(h, "/hook", testPayload, nil); code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", code, http.StatusInternalServerError)
+	}
+}
+
+func TestNewHandlerInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{name: "template not JSON", opt: WithTemplate("$CODE is $STATUS")},
+		{name: "template without uuid", opt: WithTemplate(`{"status": "$STATUS"}`)},
+		{name: "template without status", opt: WithTemplate(`{"uuid": "$CODE"}`)},
+		{name: "duplicate placeholder", opt: WithTemplate(`{"uuid": "$CODE", "id": "$CODE", "status": "$STATUS"}`)},
+		{name: "empty secret", opt: WithSecretParam("token", "")},
+		{name: "empty param", opt: WithSecretParam("", "s3cret")},
+		{name: "negative window", opt: WithDeduplication(-1)},
+		{name: "nil logger", opt: WithLogger(nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewHandler(tt.opt); err == nil {
+				t.Error("NewHandler() error = nil, want error")
+			}
+		})
+	}
+}